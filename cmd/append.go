@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var addTimestamp bool
+
+func init() {
+	addContentFlags(appendCmd)
+}
+
+// addContentFlags adds the flags append and prepend share.
+func addContentFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Text to add")
+	cmd.Flags().BoolVarP(&addTimestamp, "timestamp", "d", false, "Put the text under a heading with the current date and time")
+	addFileFlag(cmd)
+}
+
+// contentArg returns the text given with --message, --file or on stdin.
+func contentArg() (string, error) {
+	content := validation.NewValidator().CheckString(message)
+
+	if content != nil && inputFile != "" {
+		return "", errors.New("use either --message or --file")
+	}
+	if content == nil {
+		input, err := readInput()
+		if err != nil {
+			return "", err
+		}
+		content = input
+	}
+	if content == nil {
+		return "", errors.New("nothing to add, use --message, --file or pipe the text")
+	}
+
+	return *content, nil
+}
+
+var appendCmd = &cobra.Command{
+	Use:   "append [id]",
+	Short: "Add text to the end of a note without opening the editor",
+	Long: `Append text to the end of an existing note without opening the editor.
+
+The text comes from --message, from the file given with --file, or from stdin
+when it is piped. Binary and non UTF-8 input is rejected. The note can be
+referenced by ID, slug, title prefix or content hash prefix (see 'snip show --help').
+
+Each append is a single database update, so running logs kept from several
+shells or scripts at once never lose lines.
+
+Flags:
+  --message, -m    Text to add
+  --file, -f       Read the text from a file, - for stdin
+  --timestamp, -d  Put the text under a heading with the current date and time
+
+Examples:
+  snip append incident -m "rolled back api to v1.41" -d
+  kubectl logs api-7d9f | snip append incident-42
+  snip append 3 --file notes.txt
+  date | snip append standup`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeNoteArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			content, err := contentArg()
+			if err != nil {
+				return err
+			}
+			return h.AppendNote(args[0], content, addTimestamp)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
@@ -13,12 +11,13 @@ var backupCmd = &cobra.Command{
 	Long: `Create a timestamped backup of your notes database.
 
 The backup is a complete copy of the SQLite database file, preserving all notes,
-tags, relationships, and metadata. Backups are stored in ~/.snip/backups/
+tags, relationships, and metadata. Backups are stored in the backups directory of
+the notebook in use, ~/.snip/backups/ for the default notebook.
 
 This is the recommended method for backing up your notes as it:
   - Preserves the complete database structure
   - Is fast and reliable
-  - Can be easily restored by copying back to ~/.snip/notes.db
+  - Can be easily restored by copying back to the notebook's notes.db
   - Takes less space than JSON exports
 
 Examples:
@@ -27,7 +26,7 @@ Examples:
 		if err := executeWithHandler(func(h handler.Handler) error {
 			return h.BackupDatabase()
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/matheuzgomes/Snip/internal/language"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/spf13/cobra"
+)
+
+var exportFormats = []string{"json", "markdown", "raw"}
+var graphFormats = []string{"dot", "json"}
+var outputFormats = []string{"table", "json", "yaml", "tsv"}
+
+// completionShells are the shells 'snip completion install' knows about.
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// addCompletionInstallCmd adds 'install' next to the scripts cobra generates
+// under 'snip completion'.
+func addCompletionInstallCmd() {
+	rootCmd.InitDefaultCompletionCmd()
+	if completionCmd, _, err := rootCmd.Find([]string{"completion"}); err == nil && completionCmd != rootCmd {
+		completionCmd.AddCommand(completionInstallCmd)
+	}
+}
+
+// completeNoteArg offers note IDs, described by their title, for the first
+// argument. Once a letter is typed it offers slugs instead.
+func completeNoteArg(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	noteRepo, _, err := getRepository()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	notes, err := noteRepo.GetAll(false, 0, false)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return noteCompletions(notes, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeTrashArg(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	noteRepo, _, err := getRepository()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	notes, err := noteRepo.GetDeleted()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	// restore only takes IDs
+	return noteCompletions(notes, ""), cobra.ShellCompDirectiveNoFileComp
+}
+
+func noteCompletions(notes []*note.NoteWithTags, toComplete string) []cobra.Completion {
+	bySlug := toComplete != "" && !unicode.IsDigit(rune(toComplete[0]))
+
+	var completions []cobra.Completion
+	for _, n := range notes {
+		if bySlug {
+			if n.Slug != "" && strings.HasPrefix(n.Slug, strings.ToLower(toComplete)) {
+				completions = append(completions, cobra.CompletionWithDesc(n.Slug, n.Title))
+			}
+			continue
+		}
+
+		id := strconv.Itoa(n.ID)
+		if strings.HasPrefix(id, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(id, n.Title))
+		}
+	}
+	return completions
+}
+
+// completeTags offers existing tag names with their note count.
+func completeLanguages(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var completions []cobra.Completion
+	for _, name := range append([]string{language.Auto, language.None}, language.Names()...) {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	_, tagRepo, err := getRepository()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	tags, err := tagRepo.GetAll()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []cobra.Completion
+	for _, t := range tags {
+		if strings.HasPrefix(t.Name, strings.ToLower(toComplete)) {
+			completions = append(completions, cobra.CompletionWithDesc(t.Name, fmt.Sprintf("%d note(s)", t.NoteCount)))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeFirstTagArg(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeTags(cmd, args, toComplete)
+}
+
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [shell]",
+	Short: "Install the autocompletion script for your shell",
+	Long: `Write the autocompletion script where your shell loads it from. Without an
+argument the shell is taken from $SHELL.
+
+Scripts are written to:
+  bash        $XDG_DATA_HOME/bash-completion/completions/snip (needs bash-completion)
+  zsh         ~/.zfunc/_snip
+  fish        $XDG_CONFIG_HOME/fish/completions/snip.fish
+  powershell  $XDG_CONFIG_HOME/powershell/snip.ps1
+
+zsh and PowerShell need a line in their startup file to load the script, it is
+printed after the install. Start a new shell to use the completions.
+
+Examples:
+  snip completion install        # Install for the shell in $SHELL
+  snip completion install fish   # Install for fish`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: completionShells,
+	Run: func(cmd *cobra.Command, args []string) {
+		shell := filepath.Base(os.Getenv("SHELL"))
+		if len(args) > 0 {
+			shell = args[0]
+		}
+
+		if err := installCompletion(shell); err != nil {
+			printError(err)
+		}
+	},
+}
+
+func installCompletion(shell string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to find the home directory: %w", err)
+	}
+
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	var script bytes.Buffer
+	var path, hint string
+
+	switch shell {
+	case "bash":
+		path = filepath.Join(dataHome, "bash-completion", "completions", "snip")
+		err = rootCmd.GenBashCompletionV2(&script, true)
+	case "zsh":
+		path = filepath.Join(home, ".zfunc", "_snip")
+		hint = "Add to ~/.zshrc, before compinit:\n    fpath=(~/.zfunc $fpath)\n    autoload -U compinit && compinit"
+		err = rootCmd.GenZshCompletion(&script)
+	case "fish":
+		path = filepath.Join(configHome, "fish", "completions", "snip.fish")
+		err = rootCmd.GenFishCompletion(&script, true)
+	case "powershell", "pwsh":
+		path = filepath.Join(configHome, "powershell", "snip.ps1")
+		hint = fmt.Sprintf("Add to your PowerShell profile ($PROFILE):\n    . %s", path)
+		err = rootCmd.GenPowerShellCompletionWithDesc(&script)
+	case "", ".":
+		return errors.New("could not tell the shell from $SHELL, pass one of: " + strings.Join(completionShells, ", "))
+	default:
+		return fmt.Errorf("unsupported shell '%s', use one of: %s", shell, strings.Join(completionShells, ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate the %s completion: %w", shell, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create completion directory: %w", err)
+	}
+	if err := os.WriteFile(path, script.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+
+	fmt.Printf("✓ %s completion installed!\n  Location: %s\n", shell, path)
+	if hint != "" {
+		fmt.Printf("  %s\n", hint)
+	}
+	return nil
+}
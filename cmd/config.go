@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configEditCmd)
+}
+
+// loadConfig reads the config file and applies environment variables and
+// --set flags on top of it.
+func loadConfig() (string, *config.Config, error) {
+	path, err := config.Path()
+	if err != nil {
+		return "", nil, err
+	}
+
+	cfg, err := config.Load(path, configOverrides)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return path, cfg, nil
+}
+
+func executeWithConfigHandler(fn func(*handler.ConfigHandler) error) error {
+	path, cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	out, err := resultRenderer()
+	if err != nil {
+		return err
+	}
+
+	return fn(handler.NewConfigHandler(path, cfg, out))
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and change settings",
+	Long: `View and change the settings stored in ~/.snip/config.yaml.
+
+Set SNIP_CONFIG to use another file, e.g. one shared by your team. Every setting
+can also be given as an environment variable (see 'snip config list') or for a
+single command with --set key=value. The first one found wins:
+
+  flag (--set) > environment > config file > default
+
+Examples:
+  snip config list                        # Show every setting and where it comes from
+  snip config get date_format             # Print one setting
+  snip config set date_format 02/01/2006  # Save a setting to the config file
+  snip config edit                        # Open the config file in your editor
+  snip --set rows_limit=10 list           # Override a setting for one command`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithConfigHandler(func(h *handler.ConfigHandler) error {
+			return h.GetConfig(args[0])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "Save a setting to the config file",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithConfigHandler(func(h *handler.ConfigHandler) error {
+			return h.SetConfig(args[0], args[1])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Show every setting with its value and source",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithConfigHandler(func(h *handler.ConfigHandler) error {
+			return h.ListConfig()
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in your editor",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithConfigHandler(func(h *handler.ConfigHandler) error {
+			return h.EditConfig()
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
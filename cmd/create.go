@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/language"
 	"github.com/matheuzgomes/Snip/internal/validation"
 	"github.com/spf13/cobra"
 )
@@ -13,9 +15,21 @@ var message string
 
 var tag string
 
+var createLang string
+
+var noteTemplate string
+var templateVars []string
+
 func init() {
 	createCmd.Flags().StringVarP(&message, "message", "m", "", "Content of the note")
 	createCmd.Flags().StringVarP(&tag, "tag", "t", "", "Tag of the note")
+	createCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	addFileFlag(createCmd)
+	createCmd.Flags().StringVarP(&createLang, "lang", "l", "", "Code language of a snippet, or auto to detect it (default: from the --file extension)")
+	createCmd.RegisterFlagCompletionFunc("lang", completeLanguages)
+	createCmd.Flags().StringVar(&noteTemplate, "template", "", "Start the note from a template, see 'snip template list'")
+	createCmd.Flags().StringArrayVar(&templateVars, "var", nil, "Value of a template variable as key=value (repeatable)")
+	createCmd.RegisterFlagCompletionFunc("template", completeTemplates)
 }
 
 var createCmd = &cobra.Command{
@@ -24,23 +38,99 @@ var createCmd = &cobra.Command{
 	Long: `Create a new note by providing a title and optional content.
 
 The title can be multiple words and will be joined together. You can provide content
-in two ways:
+in several ways:
 1. Use the --message flag to provide content directly
-2. If no message is provided, your default editor will open for interactive content editing
-3. Use the --tag flag to provide a tag for the note
+2. Pipe it on stdin, or read it from a file with --file
+3. If no content is provided, your default editor will open for interactive content editing
+4. Use the --tag flag to provide a tag for the note
+
+A snippet has a code language, highlighted by 'snip show'. Give it with --lang
+(go, sql, python, bash...), or --lang auto to detect it from a #! line or a
+fenced code block. Content read with --file takes the language of the file
+extension unless --lang is given.
+
+Binary and non UTF-8 input is rejected.
+
+With --template the editor opens on a template from 'snip template list'. Its
+{{date}}, {{time}}, {{title}} and {{user}} variables are filled in, the others
+are given with --var or asked for. The template tags are added to the note.
 
 Examples:
   snip create "My Daily Notes"                    # Opens editor for content
   snip create "Quick Note" --message "Hello!"     # User provided message
   snip create Meeting Notes                       # Opens editor for content
-  snip create TODO --tag "shopping"               # User provided tag`,
+  snip create TODO --tag "shopping"               # User provided tag
+  kubectl logs api-7d9f | snip create incident    # Content piped on stdin
+  snip create "Runbook" --file runbook.md         # Content read from a file
+  snip create "Top queries" --file top.sql        # A snippet, its language is sql
+  snip create "Retry loop" --lang go              # A Go snippet written in the editor
+  snip create --template meeting "Sprint review"  # Start from a template
+  snip create --template adr "Use SQLite" --var status=accepted`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
 			validator := validation.NewValidator()
-			return h.CreateNote(strings.Join(args, " "), validator.CheckString(message), validator.CheckString(tag))
+			content := validator.CheckString(message)
+
+			if noteTemplate != "" {
+				if content != nil || inputFile != "" {
+					return errors.New("use either --template or --message/--file")
+				}
+				return createFromTemplate(h, strings.Join(args, " "), validator.CheckString(tag))
+			}
+			if len(templateVars) > 0 {
+				return errors.New("--var needs a --template")
+			}
+
+			if content != nil && inputFile != "" {
+				return errors.New("use either --message or --file")
+			}
+			if createLang == "" && inputFile != "" && inputFile != "-" {
+				createLang = language.FromFilename(inputFile)
+			}
+			if content == nil {
+				input, err := readInput()
+				if err != nil {
+					return err
+				}
+				content = input
+			}
+
+			return h.CreateNote(strings.Join(args, " "), content, validator.CheckString(tag), createLang)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
+
+func createFromTemplate(h handler.Handler, title string, tag *string) error {
+	store, err := templateStore()
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := store.Get(noteTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to load template: %w", err)
+	}
+
+	vars, err := parseVars("var", templateVars)
+	if err != nil {
+		return err
+	}
+
+	return h.CreateNoteFromTemplate(title, tmpl, vars, tag)
+}
+
+// parseVars reads key=value pairs given with the flag.
+func parseVars(flag string, pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --%s '%s', use key=value", flag, pair)
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars, nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/matheuzgomes/Snip/internal/database"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var migrateStatus bool
+
+func init() {
+	migrateCmd.Flags().BoolVarP(&migrateStatus, "status", "s", false, "Show applied and pending migrations without running them")
+	dbCmd.AddCommand(migrateCmd)
+}
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the notes database",
+	Long: `Maintenance commands for the SQLite database that stores your notes.
+
+Examples:
+  snip db migrate            # Apply pending schema migrations
+  snip db migrate --status   # Show which migrations are applied`,
+}
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	Long: `Bring the notes database schema up to date.
+
+Migrations are applied in order, each one inside its own transaction, and are
+recorded in the schema_version table. Every other command migrates the database
+automatically on start, so this is mostly useful to check the schema state or
+to upgrade a database explicitly after installing a new version.
+
+Flags:
+  --status, -s   Show applied and pending migrations without running them
+
+Examples:
+  snip db migrate            # Apply pending migrations
+  snip db migrate --status   # List migrations and their state`,
+	Run: func(cmd *cobra.Command, args []string) {
+		nb, err := resolveNotebook()
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		db, err := database.Open(nb.DBPath)
+		if err != nil {
+			printError(fmt.Errorf("failed to open database: %w", err))
+			return
+		}
+		defer db.Close()
+
+		out, err := resultRenderer()
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		dbHandler := handler.NewDatabaseHandler(db, out)
+		if migrateStatus {
+			err = dbHandler.ShowMigrationStatus()
+		} else {
+			err = dbHandler.Migrate()
+		}
+
+		if err != nil {
+			printError(err)
+		}
+	},
+}
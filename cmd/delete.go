@@ -1,31 +1,41 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
 
+func init() {
+	addPickFlag(deleteCmd)
+}
+
 var deleteCmd = &cobra.Command{
 	Use:   "delete [id]",
-	Short: "Permanently remove a note by ID",
-	Long: `Permanently delete a note from your collection using its unique ID.
+	Short: "Move a note to the trash by ID",
+	Long: `Delete a note from your collection using its unique ID.
+
+The note is moved to the trash, where it stays hidden until you restore it with
+'snip trash restore [id]' or remove it for good with 'snip trash empty'.
 
-This action cannot be undone. The note will be completely removed from your database.
-Make sure you have the correct note ID before executing this command.
+Without an ID, or with --interactive/-i, the note is picked with a fuzzy finder
+over titles, tags and content.
 
 Examples:
   snip delete 1        # Delete note with ID 1
   snip delete 42       # Delete note with ID 42
+  snip delete old-todo # Delete the note with slug "old-todo"
+  snip delete -i draft # Pick a note matching "draft" to delete
   
 Tip: Use 'snip list' or 'snip show [id]' to verify the note before deletion.`,
-	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
-			return h.DeleteNote(args[0])
+			id, err := noteIDArg(args)
+			if err != nil {
+				return err
+			}
+			return h.DeleteNote(id)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
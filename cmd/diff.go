@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [id] [from] [to]",
+	Short: "Show a unified diff between two revisions of a note",
+	Long: `Show what changed between two revisions of a note as a unified diff.
+
+The title and tags are compared together with the content. Revisions can be
+written as "3" or "r3", see 'snip history' for the list.
+
+Examples:
+  snip diff 1          # Compare the current revision with the previous one
+  snip diff 1 r2       # Compare revision 2 with the current revision
+  snip diff 1 r2 r5    # Compare revision 2 with revision 5`,
+	Args:              cobra.RangeArgs(1, 3),
+	ValidArgsFunction: completeNoteArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to := "", ""
+		if len(args) > 1 {
+			from = args[1]
+		}
+		if len(args) > 2 {
+			to = args[2]
+		}
+
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.DiffNote(args[0], from, to)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
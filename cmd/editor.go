@@ -12,7 +12,13 @@ var editorCmd = &cobra.Command{
 
 This command helps you understand which editor Snip will use for editing notes and shows alternatives you can configure.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		editorHandler := handler.NewEditorHandler()
+		_, cfg, err := loadConfig()
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		editorHandler := handler.NewEditorHandlerFromConfig(cfg)
 		editorHandler.ShowEditorInfo()
 	},
 }
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
@@ -12,7 +10,8 @@ var exportFormat string
 
 func init() {
 	exportCmd.Flags().StringVarP(&exportSince, "since", "s", "", "Export notes created since date or duration (e.g., '2025-01-01' or '30d')")
-	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "json", "Export format (json or markdown)")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "json", "Export format (json, markdown or raw)")
+	exportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(exportFormats, cobra.ShellCompDirectiveNoFileComp))
 }
 
 var exportCmd = &cobra.Command{
@@ -20,8 +19,11 @@ var exportCmd = &cobra.Command{
 	Short: "Export notes to JSON format",
 	Long: `Export your notes to a timestamped JSON file for migration or archival purposes.
 
-The export creates a JSON array containing notes with their metadata, content, and tags.
-Exports are stored in ~/.snip/export/
+The export creates a JSON array containing notes with their metadata, content, tags
+and properties. Markdown exports put the properties in a front matter block, so
+'snip import' reads them back.
+Exports are stored in the export directory of the notebook in use, ~/.snip/export/
+for the default notebook.
 
 Note: For backup purposes, use 'snip backup' instead, which is faster and preserves
 the complete database structure.
@@ -34,19 +36,24 @@ Export is recommended when you need to:
 Flags:
   --since, -s    Export only notes created since a specific date or duration
                  Examples: "2025-01-01", "30d", "7d", "1y"
-  --format, -f    Export format (json or markdown)
+  --format, -f    Export format (json, markdown or raw)
+
+The raw format writes each note's content as is, in a file with the extension
+of its language (.go, .sql...), or .txt for notes that are not snippets.
+
 Examples:
   snip export                      # Export all notes
   snip export --since 30d          # Export notes from last 30 days
   snip export --since "2025-01-01" # Export notes since Jan 1, 2025
   snip export -s 7d                # Export notes from last week
   snip export --format markdown    # Export notes in markdown format
-  snip export -f json              # Export notes in json format`,
+  snip export -f json              # Export notes in json format
+  snip export -f raw               # Export snippets as source files`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
 			return h.ExportNotes(exportSince, exportFormat)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
@@ -6,19 +6,37 @@ import (
 
 	"github.com/matheuzgomes/Snip/internal/database"
 	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/notebook"
 	"github.com/matheuzgomes/Snip/internal/repository"
 )
 
 var (
 	globalNoteRepo repository.NoteRepository
 	globalTagRepo  repository.TagRepository
+	globalNotebook *notebook.Notebook
 	repoOnce       sync.Once
 )
 
+// resolveNotebook returns the notebook selected by --notebook, SNIP_DB or
+// 'snip notebook use'.
+func resolveNotebook() (*notebook.Notebook, error) {
+	store, err := notebook.DefaultStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.Resolve(notebookName)
+}
+
 func getRepository() (repository.NoteRepository, repository.TagRepository, error) {
 	var err error
 	repoOnce.Do(func() {
-		db, connectErr := database.Connect()
+		globalNotebook, err = resolveNotebook()
+		if err != nil {
+			return
+		}
+
+		db, connectErr := database.Connect(globalNotebook.DBPath)
 		if connectErr != nil {
 			err = connectErr
 			return
@@ -35,7 +53,17 @@ func setupHandler() (handler.Handler, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	h := handler.NewHandler(noteRepo, tagRepo)
+	configPath, cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := newRenderer(configPath, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.WithNotebook(globalNotebook), handler.WithOutput(out))
 
 	return h, nil
 }
@@ -1,35 +1,53 @@
 package cmd
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
 
+func init() {
+	addTemplateFlag(findCmd)
+}
+
 var findCmd = &cobra.Command{
 	Use:   "find [text]",
 	Short: "Search for notes containing specific text in title or content",
-	Long: `Search through all your notes to find ones containing the specified text.
+	Long: `Search through all your notes using a small query language.
 
-The search looks through both note titles and content, returning all matches.
-Multiple search terms can be provided and will be joined together. The search
-is case-insensitive and matches partial words.
+Plain words are matched against both titles and content and every word must
+match. Results are ranked by relevance and show an excerpt with the matching
+terms highlighted.
+
+Query syntax:
+  word               Word anywhere in the title or content
+  word*              Words starting with "word"
+  "exact phrase"     Exact phrase in the title or content
+  title:deploy       Word in the title only (also content:)
+  tag:work           Notes tagged "work"
+  created:>2025-01-01  Created after a date (>, >=, <, <=, or none for that day)
+  updated:<7d        Updated within the last 7 days (>7d for older than)
+  -term              Exclude notes matching any of the terms above
+  a OR b             Either term matches
 
 Examples:
-  snip find meeting            # Find notes containing "meeting"
-  snip find "project ideas"    # Find notes with "project ideas"
-  snip find TODO urgent        # Find notes containing "TODO urgent"
-  snip find golang             # Find notes about golang
+  snip find meeting                       # Find notes containing "meeting"
+  snip find '"project ideas"'             # Find the exact phrase "project ideas"
+  snip find TODO urgent                   # Find notes containing both words
+  snip find tag:work -tag:old title:deploy
+  snip find 'rollback OR revert updated:<30d'
+
+Results can be formatted with --template/-T (see 'snip list --help'), which
+also sees .Highlight, .Snippet and .Rank.
 
-Tip: Use quotes for exact phrases, or separate words for broader matching.`,
+Tip: quote the whole query in your shell when it contains quotes, < or >.`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
 			return h.FindNotes(strings.Join(args, " "))
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
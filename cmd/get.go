@@ -1,17 +1,17 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
 
-var render bool
+var renderMarkdown bool
 
 func init() {
 	showCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show more information about the notes")
-	showCmd.Flags().BoolVarP(&render, "render", "r", false, "Render the note markdown")
+	showCmd.Flags().BoolVarP(&renderMarkdown, "render", "r", false, "Render the note markdown")
+	addTemplateFlag(showCmd)
+	addPickFlag(showCmd)
 }
 
 var showCmd = &cobra.Command{
@@ -22,22 +22,41 @@ var showCmd = &cobra.Command{
 This command shows the note's title, content and tags in a readable format. Use the verbose
 flag to see additional metadata like creation and modification timestamps.
 
+Besides its ID, a note can be referenced by its slug (shown with --verbose), a
+title or the start of one, or the start of its content hash. When a reference
+matches several notes, the candidates are listed.
+
+Notes referenced with [[Note Title]] or [[#42]] are listed under Links, and notes
+referencing this one under Backlinks.
+
 Flags:
-  --verbose, -v  Show detailed metadata (timestamps, ID, etc.)
+  --verbose, -v  Show detailed metadata (timestamps, slug, properties, content hash)
   --render, -r   Render the note markdown content (default is false)
+  --template, -T Format the note with a template (see 'snip list --help'),
+                 .Links and .Backlinks are also available
+  --interactive, -i  Pick the note with a fuzzy finder, also used when no ID is given
 
 Examples:
   snip show 1              # Display note with ID 1
   snip show 42             # Display note with ID 42  
+  snip show meeting-notes  # Display the note with slug "meeting-notes"
+  snip show meet           # Display the only note whose title starts with "meet"
+  snip show 3f9a2c1        # Display the note whose content hash starts with 3f9a2c1
   snip show 1 --verbose    # Show note 1 with full metadata
   snip show 1 -v           # Same as above (short flag)
-  snip show 1 -r           # Render note 1 markdown content`,
-	Args: cobra.ExactArgs(1),
+  snip show 1 -r           # Render note 1 markdown content
+  snip show                # Pick the note with the fuzzy finder
+  snip show -i k8s | less  # Start the search with "k8s", the picker stays on the terminal
+  snip show 1 -T '{{.Title}}{{"\n"}}{{markdown .Content}}'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
-			return h.GetNote(args[0], verbose, render)
+			id, err := noteIDArg(args)
+			if err != nil {
+				return err
+			}
+			return h.GetNote(id, verbose, renderMarkdown)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+var graphFile string
+var graphTag string
+var graphSince string
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "dot", "Graph format (dot or json)")
+	graphCmd.Flags().StringVarP(&graphFile, "file", "w", "", "Write the graph to this file instead of stdout")
+	graphCmd.Flags().StringVarP(&graphTag, "tag", "t", "", "Only include notes with this tag or one of its subtags")
+	graphCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(graphFormats, cobra.ShellCompDirectiveNoFileComp))
+	graphCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	graphCmd.Flags().StringVarP(&graphSince, "since", "s", "", "Only include notes created since date or duration (e.g., '2025-01-01' or '30d')")
+}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export notes, tags and links as a graph",
+	Long: `Export the structure of your notes as a Graphviz DOT file or a node/edge JSON document.
+
+Notes and tags are the nodes. Edges go from a note to the notes it links to with
+[[Note Title]] or [[#42]], from a note to its tags and from a tag to its parent tag.
+Broken links are left out.
+
+Flags:
+  --format, -f   Graph format: dot (default) or json
+  --file, -w     Write to a file instead of stdout
+  --tag, -t      Only include notes with this tag or one of its subtags
+  --since, -s    Only include notes created since a date or duration
+                 Examples: "2025-01-01", "30d", "7d", "1y"
+
+Examples:
+  snip graph | dot -Tsvg > notes.svg       # Render with Graphviz
+  snip graph --format json -w graph.json   # Node/edge JSON document
+  snip graph --tag work --since 30d        # Work notes from the last 30 days`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.ExportGraph(graphFormat, graphFile, graphTag, graphSince)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [id]",
+	Short: "List the revisions of a note",
+	Long: `List every saved revision of a note, newest first.
+
+A revision is recorded each time a note is created, updated, patched or
+reverted. Use 'snip diff' to compare two revisions and 'snip revert' to bring
+an old one back.
+
+By default the latest 100 revisions of each note are kept. Change the
+history_limit setting ('snip config set history_limit 20') or the
+SNIP_HISTORY_LIMIT environment variable, or set it to 0 to keep every revision.
+
+Examples:
+  snip history 1       # List revisions of note 1
+  snip history deploy  # List revisions of the note whose title starts with "deploy"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeNoteArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.ShowHistory(args[0])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
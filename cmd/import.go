@@ -1,34 +1,45 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
 
 var importDir string
+var importSnippets bool
 
 func init() {
 	importCmd.Flags().StringVarP(&importDir, "dir", "d", "", "Directory to import notes from")
+	importCmd.Flags().BoolVarP(&importSnippets, "snippets", "s", false, "Also import source files (.go, .sql, .sh...) as snippets")
 }
 
 var importCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import markdown notes from a directory",
-	Long: `Import markdown notes from a directory into the database.
+	Long: `Import markdown and JSON notes from a directory into the database.
+
+A markdown note is named after its file. When it starts with a YAML front
+matter block, its title, tags and language keys set those of the note and every
+other key becomes a property, without a language key it is detected from a
+fenced block or #! line. JSON files are read as written by 'snip export'.
+
+Source files (.go, .sql, .sh...) are only imported with --snippets, as snippets
+in the language of their extension named after the file. Dotfiles such as
+.bashrc are always skipped.
 
 Flags:
-  --dir, -d    Directory to import notes from starting from your home directory
+  --dir, -d        Directory to import notes from starting from your home directory
+  --snippets, -s   Also import source files as snippets
 Examples:
   snip import                      # Import all markdown notes from the current directory
   snip import --dir notes          # Snip will look for notes starting from your home directory so in this example it will look for notes in ~/notes
-  snip import -d notes/work        # Snip will look for notes starting from your home directory so in this example it will look for notes in ~/notes/work`,
+  snip import -d notes/work        # Snip will look for notes starting from your home directory so in this example it will look for notes in ~/notes/work
+  snip import -d snippets --snippets   # Also import ~/snippets/*.go, *.sql... as snippets`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
-			return h.ImportNotes(importDir)
+			return h.ImportNotes(importDir, importSnippets)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var inputFile string
+
+var errEmptyInput = errors.New("no content to read, the input is empty")
+
+// addFileFlag lets a command read note content from a file.
+func addFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&inputFile, "file", "f", "", "Read the content from a file, - for stdin")
+}
+
+// readInput returns the content given with --file, or piped on stdin. It
+// returns nil when there is neither, so the caller can fall back to the editor.
+func readInput() (*string, error) {
+	var r io.Reader
+
+	switch {
+	case inputFile == "-":
+		r = os.Stdin
+	case inputFile != "":
+		f, err := os.Open(inputFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open input file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	case stdinIsPiped():
+		r = os.Stdin
+	default:
+		return nil, nil
+	}
+
+	content, err := handler.ReadContent(r)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" {
+		return nil, errEmptyInput
+	}
+
+	return &content, nil
+}
+
+// stdinIsPiped reports whether stdin is a pipe or a file rather than a
+// terminal.
+func stdinIsPiped() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+// journalWeek is how many entries 'snip journal --week' shows.
+const journalWeek = 7
+
+var journalShowWeek bool
+
+func init() {
+	journalCmd.Flags().BoolVarP(&journalShowWeek, "week", "w", false, "Show the last seven journal entries together")
+}
+
+var journalCmd = &cobra.Command{
+	Use:   "journal [date]",
+	Short: "Open the journal note of a day",
+	Long: `Open the journal note of a day in your editor, creating it on first use.
+
+Journal notes are titled with their date (2025-03-14), which is also their slug,
+and tagged journal. A date never gets two journal notes, and a new one is not
+created while another note has its date as slug. New ones start from the
+journal_template setting, a Go template where .Date is the day:
+
+  snip config set journal_template '# {{date .Date "Monday, January 2"}}\n\n## Todo\n\n## Log\n'
+
+The date is written as 2025-03-14 or as how long ago it was (3d, 1w), and
+defaults to today. See also 'snip today' and 'snip yesterday'.
+
+Flags:
+  --week, -w     Show the last seven journal entries together instead
+
+Examples:
+  snip journal               # Today's note
+  snip journal 2025-03-14    # The note of March 14, 2025
+  snip journal 3d            # The note of three days ago
+  snip journal --week        # Read back the last seven entries
+  snip append "$(date +%F)" -m "deployed v2" -d   # Log to today's note`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			if journalShowWeek {
+				if len(args) > 0 {
+					return errors.New("--week shows the latest entries, it takes no date")
+				}
+				return h.ShowJournal(journalWeek)
+			}
+
+			date := ""
+			if len(args) > 0 {
+				date = args[0]
+			}
+			return h.OpenJournal(date)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "Open today's journal note",
+	Long: `Open today's journal note in your editor, creating it on first use.
+Same as 'snip journal', see 'snip journal --help'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.OpenJournal("today")
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var yesterdayCmd = &cobra.Command{
+	Use:   "yesterday",
+	Short: "Open yesterday's journal note",
+	Long: `Open yesterday's journal note in your editor, creating it on first use.
+Same as 'snip journal 1d', see 'snip journal --help'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.OpenJournal("yesterday")
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
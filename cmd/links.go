@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var brokenLinks bool
+
+func init() {
+	linksCmd.Flags().BoolVarP(&brokenLinks, "broken", "b", false, "Only show links that point to no note")
+}
+
+var linksCmd = &cobra.Command{
+	Use:   "links",
+	Short: "List the links between notes",
+	Long: `List the links written in notes as [[Note Title]] or [[#42]].
+
+Title links are matched case-insensitively. A link is broken when no note outside
+the trash has that title or ID.
+
+Flags:
+  --broken, -b   Only show broken links
+
+Examples:
+  snip links             # Show every link
+  snip links --broken    # Show links that point to no note`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.ShowLinks(brokenLinks)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/matheuzgomes/Snip/internal/validation"
 	"github.com/spf13/cobra"
@@ -11,11 +9,20 @@ import (
 var isAsc bool
 var verbose bool
 var listTag string
+var listSubtags bool
+var listWhere []string
+var listLang string
 
 func init() {
 	listCmd.Flags().BoolVarP(&isAsc, "asc", "a", false, "List notes in chronological order (oldest first)")
 	listCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show more information about the notes")
 	listCmd.Flags().StringVarP(&listTag, "tag", "t", "", "List notes by tag")
+	listCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	listCmd.Flags().BoolVarP(&listSubtags, "subtags", "s", false, "Include notes of the tag's subtags when listing by tag")
+	listCmd.Flags().StringArrayVarP(&listWhere, "where", "w", nil, "Only show notes whose properties match, e.g. 'priority>=2' (repeatable)")
+	listCmd.Flags().StringVarP(&listLang, "lang", "l", "", "Only show snippets in this code language")
+	listCmd.RegisterFlagCompletionFunc("lang", completeLanguages)
+	addTemplateFlag(listCmd)
 }
 
 var listCmd = &cobra.Command{
@@ -27,11 +34,26 @@ var listCmd = &cobra.Command{
 By default, notes are displayed with newest first (descending order by creation date).
 You can control the output format and sorting to match your workflow preferences.
 
-You can also list notes by tag using the --tag flag.
+You can also list notes by tag using the --tag flag. Tags can be nested with "/"
+(work/infra/k8s); add --subtags to include the notes of every tag below the given one.
 
 Flags:
   --asc, -a      Sort chronologically (oldest first)
   --verbose, -v  Show detailed information including timestamps and IDs
+  --tag, -t      Only show notes with this tag
+  --subtags, -s  With --tag, also show notes of its subtags
+  --lang, -l     Only show snippets in this code language (go, sql...)
+  --where, -w    Only show notes whose properties match a condition; repeat
+                 it to require several. Conditions are key (has the
+                 property), key=value, key!=value, key>value, key>=value,
+                 key<value and key<=value. Numbers and dates compare by
+                 order, lists match when they contain the value.
+  --template, -T Format each note with a Go template, or the name of a
+                 template saved as ~/.snip/templates/<name>.tmpl
+
+Templates see the note fields (.ID, .Title, .Content, .Tags, .CreatedAt,
+.UpdatedAt, .Language, .Properties with .Properties.Get "status") and these helpers: join .Tags ",", date .CreatedAt [layout],
+truncate 40 .Content, wrap 80 .Content, oneline .Content, markdown .Content.
 
 Examples:
   snip list                    # Show newest notes first (default)
@@ -39,13 +61,19 @@ Examples:
   snip list --asc              # Show oldest notes first
   snip list -v                 # Show detailed note information
   snip list --asc --verbose    # Oldest first with full details
-  snip list --tag "tag"        # List notes by tag`,
+  snip list --tag "tag"        # List notes by tag
+  snip list --tag work -s      # Notes tagged work, work/infra, work/infra/k8s...
+  snip list --lang go          # Go snippets
+  snip list --where 'priority>=2' --where status!=done
+  snip list -w 'due<2025-04-01' -w owners=ana
+  snip list -T '{{.ID}}\t{{.Title}}\t{{join .Tags ","}}'
+  snip list -T compact         # Use ~/.snip/templates/compact.tmpl`,
 	Run: func(cmd *cobra.Command, args []string) {
 		validator := validation.NewValidator()
 		if err := executeWithHandler(func(h handler.Handler) error {
-			return h.ListNotes(isAsc, verbose, validator.CheckString(listTag))
+			return h.ListNotes(isAsc, verbose, validator.CheckString(listTag), listSubtags, listWhere, listLang)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/notebook"
+	"github.com/spf13/cobra"
+)
+
+var removeNotebookForce bool
+
+func init() {
+	notebookRemoveCmd.Flags().BoolVarP(&removeNotebookForce, "yes", "y", false, "Remove without asking for confirmation")
+
+	notebookCmd.AddCommand(notebookCreateCmd)
+	notebookCmd.AddCommand(notebookListCmd)
+	notebookCmd.AddCommand(notebookUseCmd)
+	notebookCmd.AddCommand(notebookRemoveCmd)
+}
+
+func executeWithNotebookHandler(fn func(*handler.NotebookHandler) error) error {
+	store, err := notebook.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	out, err := resultRenderer()
+	if err != nil {
+		return err
+	}
+
+	return fn(handler.NewNotebookHandler(store, out))
+}
+
+var notebookCmd = &cobra.Command{
+	Use:     "notebook",
+	Aliases: []string{"nb"},
+	Short:   "Manage notebooks",
+	Long: `Keep separate sets of notes, e.g. work and personal, each in its own database.
+
+Backups, exports and the trash all belong to a notebook. The notebook in use is
+chosen in this order:
+  1. the --notebook flag
+  2. the SNIP_DB environment variable, a path to a database file
+  3. the notebook selected with 'snip notebook use'
+  4. the default notebook (~/.snip/notes.db)
+
+Examples:
+  snip notebook create work     # Create a notebook
+  snip notebook list            # Show notebooks, ● marks the one in use
+  snip notebook use work        # Switch to a notebook
+  snip list --notebook default  # Run one command in another notebook
+  snip notebook remove work     # Delete a notebook and its notes`,
+}
+
+var notebookCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a notebook",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithNotebookHandler(func(h *handler.NotebookHandler) error {
+			return h.CreateNotebook(args[0])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var notebookListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Show every notebook",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithNotebookHandler(func(h *handler.NotebookHandler) error {
+			return h.ListNotebooks()
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var notebookUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Switch to a notebook",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithNotebookHandler(func(h *handler.NotebookHandler) error {
+			return h.UseNotebook(args[0])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var notebookRemoveCmd = &cobra.Command{
+	Use:     "remove [name]",
+	Aliases: []string{"rm"},
+	Short:   "Delete a notebook with its notes, backups and exports",
+	Long: `Delete a notebook with its notes, backups and exports. This cannot be undone.
+The default notebook cannot be removed.
+
+Flags:
+  --yes, -y      Remove without asking for confirmation`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithNotebookHandler(func(h *handler.NotebookHandler) error {
+			return h.RemoveNotebook(args[0], removeNotebookForce)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var outputFormat string
+var outputTemplate string
+
+// errCommandFailed makes snip exit with a non-zero status once a command has
+// printed its error.
+var errCommandFailed = errors.New("command failed")
+
+var commandFailed bool
+
+// addTemplateFlag lets a command format its notes with --template.
+func addTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&outputTemplate, "template", "T", "", "Format notes with a Go template or the name of one in ~/.snip/templates")
+}
+
+// resultRenderer returns the renderer selected with --output, for commands
+// that report results rather than notes and so have no --template.
+func resultRenderer() (*render.Renderer, error) {
+	format, err := render.ParseFormat(outputFormat)
+	if err != nil {
+		return nil, err
+	}
+	return render.New(format, os.Stdout), nil
+}
+
+// newRenderer returns the renderer selected with --output or --template.
+// Named templates live in a templates directory next to the config file.
+func newRenderer(configPath string, cfg *config.Config) (*render.Renderer, error) {
+	out, err := resultRenderer()
+	if err != nil {
+		return nil, err
+	}
+
+	if outputTemplate == "" {
+		return out, nil
+	}
+	if out.Structured() {
+		return nil, fmt.Errorf("use either --output or --template")
+	}
+
+	opts := render.TemplateOptions{DateFormat: cfg.DateFormat, MarkdownWidth: cfg.MarkdownWidth}
+	tmpl, err := render.LoadTemplate(filepath.Join(filepath.Dir(configPath), "templates"), outputTemplate, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return render.NewTemplate(tmpl, os.Stdout), nil
+}
+
+// printError reports a failed command, as {"error": ...} when a structured
+// output format is selected.
+func printError(err error) {
+	commandFailed = true
+
+	if format, formatErr := render.ParseFormat(outputFormat); formatErr == nil && format != render.FormatTable {
+		if render.New(format, os.Stdout).Error(err) == nil {
+			return
+		}
+	}
+
+	fmt.Printf("Error: %v\n", err)
+}
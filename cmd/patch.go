@@ -1,14 +1,14 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
 
 var patchTitle string
 var patchTag string
+var patchRewriteLinks bool
+var patchLang string
 
 func init() {
 	patchCmd.Flags().StringVarP(
@@ -25,6 +25,17 @@ func init() {
 		"",
 		"If you want to update the tag, you can use this flag e.g. --tag 'Tag' or --tag 'Tag1 Tag2'",
 	)
+	patchCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	patchCmd.Flags().BoolVarP(
+		&patchRewriteLinks,
+		"rewrite-links",
+		"l",
+		false,
+		"When the title changes, update [[Old Title]] links in other notes to the new title",
+	)
+	patchCmd.Flags().StringVar(&patchLang, "lang", "", "Set the code language of a snippet, auto to detect it or none to clear it")
+	patchCmd.RegisterFlagCompletionFunc("lang", completeLanguages)
+	addPickFlag(patchCmd)
 }
 
 var patchCmd = &cobra.Command{
@@ -36,19 +47,29 @@ You can also change the note's title using the --title flag.
 Flags:
   --title, -t    Update the note's title (optional)
   --tag, -a      Update the note's tag (optional)
+  --lang         Set the code language, auto to detect it, none to clear it (optional)
+  --rewrite-links, -l  Update [[links]] to the old title in other notes (optional)
+  --interactive, -i  Pick the note with a fuzzy finder, also used when no ID is given
 
 Examples:
   snip patch 1                           # Patch note 1
   snip patch 1 --title "New Title"       # Patch note 1 with new title
+  snip patch standup --tag "Meeting"     # Patch the note whose title starts with "standup"
   snip patch 42 --tag "Meeting"  		 # Patch note 42 with new tag
   snip patch 42 --title "New Title" --tag "Meeting"  # Patch note 42 with new title and tag
-  snip patch 42 --title "New Title" --tag "Meeting Technology"  # Patch note 42 with new title and two new tags`,
-	Args: cobra.ExactArgs(1),
+  snip patch 42 --title "New Title" --tag "Meeting Technology"  # Patch note 42 with new title and two new tags
+  snip patch 42 --title "New Title" --rewrite-links  # Rename note 42 and fix links pointing to it
+  snip patch 42 --lang sql               # Highlight note 42 as SQL
+  snip patch --tag "Meeting"             # Pick the note to tag`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
-			return h.PatchNote(args[0], &patchTitle, &patchTag)
+			id, err := noteIDArg(args)
+			if err != nil {
+				return err
+			}
+			return h.PatchNote(id, &patchTitle, &patchTag, &patchLang, patchRewriteLinks)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
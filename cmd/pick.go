@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var pickInteractive bool
+
+// addPickFlag lets a command that takes a note ID pick the note instead.
+func addPickFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&pickInteractive, "interactive", "i", false, "Pick the note with a fuzzy finder, the arguments start the search")
+	cmd.Args = pickArgs
+	cmd.ValidArgsFunction = completeNoteArg
+}
+
+// pickArgs accepts an optional note ID, or search words with --interactive.
+func pickArgs(cmd *cobra.Command, args []string) error {
+	if pickInteractive {
+		return nil
+	}
+	return cobra.MaximumNArgs(1)(cmd, args)
+}
+
+// noteIDArg returns the note ID given as argument. Without one, or with
+// --interactive, the note is picked with the fuzzy finder over titles, tags
+// and content.
+func noteIDArg(args []string) (string, error) {
+	if len(args) > 0 && !pickInteractive {
+		return args[0], nil
+	}
+
+	noteRepo, _, err := getRepository()
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	notes, err := noteRepo.GetAll(false, 0, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch notes: %w", err)
+	}
+
+	picked, err := tui.Pick(notes, strings.Join(args, " "))
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(picked.ID), nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addContentFlags(prependCmd)
+}
+
+var prependCmd = &cobra.Command{
+	Use:   "prepend [id]",
+	Short: "Add text to the start of a note without opening the editor",
+	Long: `Prepend text to the start of an existing note without opening the editor,
+so the latest entry of a log is the first thing 'snip show' prints.
+
+The text comes from --message, from the file given with --file, or from stdin
+when it is piped. It works like 'snip append', see 'snip append --help'.
+
+Flags:
+  --message, -m    Text to add
+  --file, -f       Read the text from a file, - for stdin
+  --timestamp, -d  Put the text under a heading with the current date and time
+
+Examples:
+  snip prepend incident -m "paged the database team" -d
+  snip prepend changelog --file release-notes.md`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeNoteArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			content, err := contentArg()
+			if err != nil {
+				return err
+			}
+			return h.PrependNote(args[0], content, addTimestamp)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
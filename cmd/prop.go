@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	propCmd.AddCommand(propSetCmd)
+	propCmd.AddCommand(propUnsetCmd)
+}
+
+var propCmd = &cobra.Command{
+	Use:     "prop",
+	Aliases: []string{"property"},
+	Short:   "Manage note properties",
+	Long: `Set and remove typed properties on a note, like status=done or priority=2.
+
+The type of a value is guessed from how it looks:
+  true, false          bool
+  2, -1, 2.5           number
+  2025-03-14           date
+  [a, b, c]            list
+  anything else        string, quote it to keep a number or date as text
+
+Properties show in 'snip show -v', in the editor front matter and in exports.
+Filter notes on them with 'snip list --where'.
+
+Examples:
+  snip prop set 42 status=done priority=2
+  snip prop set 42 due=2025-03-14 owners="[ana, rui]"
+  snip prop set 42 version="'2'"       # the string "2", not a number
+  snip prop unset 42 due`,
+}
+
+var propSetCmd = &cobra.Command{
+	Use:               "set [id] [key=value...]",
+	Short:             "Set properties on a note",
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeNoteArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.SetProperties(args[0], args[1:])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var propUnsetCmd = &cobra.Command{
+	Use:               "unset [id] [keys...]",
+	Aliases:           []string{"rm"},
+	Short:             "Remove properties from a note",
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completePropertyArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.UnsetProperties(args[0], args[1:])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+// completePropertyArg offers notes for the first argument, then the keys of
+// that note's properties.
+func completePropertyArg(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeNoteArg(cmd, args, toComplete)
+	}
+
+	noteRepo, _, err := getRepository()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	notes, err := noteRepo.GetAll(false, 0, false)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []cobra.Completion
+	for _, n := range notes {
+		if strconv.Itoa(n.ID) != args[0] && n.Slug != args[0] {
+			continue
+		}
+		for _, p := range n.Properties {
+			if strings.HasPrefix(p.Key, toComplete) {
+				completions = append(completions, cobra.CompletionWithDesc(p.Key, p.String()))
+			}
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
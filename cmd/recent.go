@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
@@ -11,6 +9,7 @@ var limit int
 
 func init() {
 	recentCmd.Flags().IntVarP(&limit, "limit", "l", 10, "Limit the number of notes to display")
+	addTemplateFlag(recentCmd)
 }
 
 var recentCmd = &cobra.Command{
@@ -23,17 +22,19 @@ By default, notes are displayed with newest first (descending order by updated d
 
 Flags:
   --limit, -l      Limit the number of notes to display
+  --template, -T   Format each note with a template (see 'snip list --help')
 
 Examples:
   snip recent                    # Show newest notes first (default)
   snip rec                       # Same as above (alias)
   snip recent --limit 10         # Show 10 recent notes
-  snip recent -l 10              # Same as above (short flag)`,
+  snip recent -l 10              # Same as above (short flag)
+  snip recent -T '{{date .UpdatedAt "Jan 02"}} {{.Title}}'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
 			return h.GetRecentNotes(limit)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
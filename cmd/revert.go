@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var revertCmd = &cobra.Command{
+	Use:   "revert [id] [revision]",
+	Short: "Restore an old revision of a note",
+	Long: `Restore the title, content and tags of an old revision.
+
+The restored state is saved as a new revision, so reverting never loses the
+history in between and can itself be reverted.
+
+Examples:
+  snip revert 1 r3     # Bring note 1 back to revision 3
+  snip revert 1 3      # Same as above`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeNoteArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.RevertNote(args[0], args[1])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
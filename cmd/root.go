@@ -9,16 +9,36 @@ var rootCmd = &cobra.Command{
 It allows you to create, edit, view, and delete notes quickly and efficiently.`,
 }
 
+var notebookName string
+var configOverrides []string
+
 func Execute() error {
-	return rootCmd.Execute()
+	if err := rootCmd.Execute(); err != nil {
+		return err
+	}
+	if commandFailed {
+		return errCommandFailed
+	}
+	return nil
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVarP(&notebookName, "notebook", "n", "", "Notebook to use for this command (see 'snip notebook')")
+	rootCmd.PersistentFlags().StringArrayVar(&configOverrides, "set", nil, "Override a setting for this command, e.g. --set rows_limit=10 (see 'snip config')")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "Output format: table, json, yaml or tsv")
+	rootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(outputFormats, cobra.ShellCompDirectiveNoFileComp))
+
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(useCmd)
 	rootCmd.AddCommand(findCmd)
 	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(appendCmd)
+	rootCmd.AddCommand(prependCmd)
+	rootCmd.AddCommand(journalCmd)
+	rootCmd.AddCommand(todayCmd)
+	rootCmd.AddCommand(yesterdayCmd)
 	rootCmd.AddCommand(patchCmd)
 	rootCmd.AddCommand(deleteCmd)
 	rootCmd.AddCommand(editorCmd)
@@ -26,4 +46,19 @@ func init() {
 	rootCmd.AddCommand(backupCmd)
 	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(dbCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(revertCmd)
+	rootCmd.AddCommand(trashCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(propCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(linksCmd)
+	rootCmd.AddCommand(graphCmd)
+	rootCmd.AddCommand(notebookCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(tuiCmd)
+
+	addCompletionInstallCmd()
 }
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var mergeInto string
+
+func init() {
+	tagMergeCmd.Flags().StringVarP(&mergeInto, "into", "i", "", "Tag that receives the notes of the merged tags")
+	tagMergeCmd.RegisterFlagCompletionFunc("into", completeTags)
+
+	tagCmd.AddCommand(tagListCmd)
+	tagCmd.AddCommand(tagTreeCmd)
+	tagCmd.AddCommand(tagRenameCmd)
+	tagCmd.AddCommand(tagMergeCmd)
+	tagCmd.AddCommand(tagDeleteCmd)
+	tagCmd.AddCommand(tagPruneCmd)
+}
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage tags",
+	Long: `List, rename, merge and delete the tags used to organize your notes.
+
+Tag names are case-insensitive: "Work" and "work" are the same tag.
+Tags can be nested with "/": tagging a note work/infra/k8s also creates the
+work and work/infra parent tags.
+
+Examples:
+  snip tag list                    # Show every tag with its note count
+  snip tag tree                    # Show the tag hierarchy
+  snip tag rename infra ops        # Rename a tag on every note
+  snip tag merge todo tasks --into work   # Move notes of todo and tasks to work
+  snip tag delete old              # Remove a tag from every note
+  snip tag prune                   # Remove tags that no note uses`,
+}
+
+var tagListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Show every tag with its note count",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.ListTags()
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var tagTreeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show the tag hierarchy",
+	Long: `Show nested tags as a tree. Each count includes the notes of the tag's subtags.
+
+Example output:
+  ● work (5)
+  ├── docs (1)
+  └── infra (3)
+      └── k8s (2)`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.ShowTagTree()
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var tagRenameCmd = &cobra.Command{
+	Use:   "rename [old] [new]",
+	Short: "Rename a tag on every note",
+	Long: `Rename a tag on every note. Subtags move along with it, so renaming work to
+job turns work/infra into job/infra. Use a path to move a tag under another one.
+
+Examples:
+  snip tag rename infra ops
+  snip tag rename work job
+  snip tag rename k8s work/infra/k8s`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeFirstTagArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.RenameTag(args[0], args[1])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var tagMergeCmd = &cobra.Command{
+	Use:   "merge [tags...] --into [tag]",
+	Short: "Merge several tags into one",
+	Long: `Move every note of the given tags to the --into tag and delete the merged tags.
+The target tag is created when it does not exist yet.
+
+Examples:
+  snip tag merge todo tasks --into work
+  snip tag merge k8s -i kubernetes`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeTags,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.MergeTags(args, mergeInto)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var tagDeleteCmd = &cobra.Command{
+	Use:               "delete [tag]",
+	Short:             "Delete a tag and remove it from every note",
+	Long:              `Delete a tag and its subtags and remove them from every note. The notes themselves are kept.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstTagArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.DeleteTag(args[0])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var tagPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove tags that no note uses",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.PruneTags()
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
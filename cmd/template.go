@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/notetemplate"
+	"github.com/spf13/cobra"
+)
+
+var removeTemplateForce bool
+
+func init() {
+	addFileFlag(templateAddCmd)
+	templateRemoveCmd.Flags().BoolVarP(&removeTemplateForce, "yes", "y", false, "Remove without asking for confirmation")
+
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateAddCmd)
+	templateCmd.AddCommand(templateEditCmd)
+	templateCmd.AddCommand(templateRemoveCmd)
+}
+
+// templateStore returns the note templates kept next to the config file, so a
+// team sharing its config with SNIP_CONFIG shares its templates too.
+func templateStore() (*notetemplate.Store, error) {
+	path, err := config.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return notetemplate.NewStore(filepath.Join(filepath.Dir(path), "note-templates")), nil
+}
+
+func executeWithTemplateHandler(fn func(*handler.TemplateHandler) error) error {
+	store, err := templateStore()
+	if err != nil {
+		return err
+	}
+
+	_, cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	out, err := resultRenderer()
+	if err != nil {
+		return err
+	}
+
+	return fn(handler.NewTemplateHandler(store, cfg, out))
+}
+
+// completeTemplates offers the names of the note templates.
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	store, err := templateStore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	templates, err := store.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []cobra.Completion
+	for _, t := range templates {
+		if strings.HasPrefix(t.Name, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(t.Name, t.Description))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeTemplateArg(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeTemplates(cmd, args, toComplete)
+}
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage note templates",
+	Long: `Keep standard layouts, such as meeting notes, postmortems or ADRs, to start
+new notes from with 'snip create --template <name>'.
+
+Templates are markdown files in ~/.snip/note-templates, next to the config file,
+so a team sharing its config with SNIP_CONFIG shares its templates too. They can
+use these variables:
+  {{date}} {{time}}    when the note is created
+  {{title}}            title of the note
+  {{user}}             your user name
+  {{name}}             anything else, given with --var name=value or asked for
+  {{name:default}}     a variable with a default value
+
+An optional front matter block sets a description, default tags and the
+question asked for each variable:
+
+  ---
+  description: Blameless postmortem
+  tags: [postmortem]
+  prompts:
+    - name: service
+      prompt: Which service was affected?
+      default: api
+  ---
+  # {{title}}
+
+  Service: {{service}}
+
+Examples:
+  snip template add postmortem                # Write a template in your editor
+  snip template add adr --file adr.md         # Add a template from a file
+  snip template list                          # Show the templates
+  snip template edit postmortem               # Change a template
+  snip create --template postmortem "API outage" --var service=billing`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Show every template",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTemplateHandler(func(h *handler.TemplateHandler) error {
+			return h.ListTemplates()
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var templateAddCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Add a template",
+	Long: `Add a template from a file, from stdin or, without input, by writing it in
+your editor.
+
+Flags:
+  --file, -f     Read the template from a file, - for stdin`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTemplateHandler(func(h *handler.TemplateHandler) error {
+			content, err := readInput()
+			if err != nil {
+				return err
+			}
+			return h.AddTemplate(args[0], content)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var templateEditCmd = &cobra.Command{
+	Use:               "edit [name]",
+	Short:             "Open a template in your editor",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTemplateArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTemplateHandler(func(h *handler.TemplateHandler) error {
+			return h.EditTemplate(args[0])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var templateRemoveCmd = &cobra.Command{
+	Use:               "remove [name]",
+	Aliases:           []string{"rm"},
+	Short:             "Delete a template",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTemplateArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTemplateHandler(func(h *handler.TemplateHandler) error {
+			return h.RemoveTemplate(args[0], removeTemplateForce)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
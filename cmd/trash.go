@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var trashOlderThan string
+var trashForce bool
+
+func init() {
+	trashEmptyCmd.Flags().StringVarP(&trashOlderThan, "older-than", "o", "", "Only purge notes trashed before a date or duration (e.g., '2025-01-01' or '30d')")
+	trashEmptyCmd.Flags().BoolVarP(&trashForce, "yes", "y", false, "Do not ask for confirmation")
+
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashEmptyCmd)
+}
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List, restore or purge deleted notes",
+	Long: `Deleted notes are moved to the trash instead of being removed right away.
+
+Notes in the trash are hidden from list, find, recent, show and export until
+they are restored or the trash is emptied.
+
+Examples:
+  snip trash list                      # Show notes in the trash
+  snip trash restore 42                # Bring note 42 back
+  snip trash empty                     # Permanently delete everything in the trash
+  snip trash empty --older-than 30d    # Only purge notes trashed over 30 days ago`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Show notes in the trash",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.ListTrash()
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:               "restore [id]",
+	Short:             "Restore a note from the trash",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTrashArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.RestoreNote(args[0])
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Permanently delete notes in the trash",
+	Long: `Permanently delete notes in the trash. This action cannot be undone.
+
+Flags:
+  --older-than, -o   Only purge notes trashed before a date or duration
+                     Examples: "2025-01-01", "30d", "2w", "6m", "1y"
+  --yes, -y          Do not ask for confirmation
+
+Examples:
+  snip trash empty                     # Purge everything, after confirmation
+  snip trash empty --older-than 30d    # Purge notes trashed over 30 days ago
+  snip trash empty -o 1y --yes         # Purge notes trashed over a year ago, no prompt`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.EmptyTrash(trashOlderThan, trashForce)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse notes in a full-screen terminal interface",
+	Long: `Browse your notes in a full-screen interface with a tag sidebar, the note
+list and a live markdown preview of the selected note.
+
+Everything is driven by the keyboard, so it works the same over SSH.
+
+Keys:
+  ↑/k ↓/j        Move in the focused pane
+  tab/shift+tab  Switch between tags, notes and preview
+  /              Search as you type (same syntax as 'snip find')
+  n              New note in your editor, tagged with the selected tag
+  e              Edit the selected note in your editor
+  t              Replace the tags of the selected note
+  d              Move the selected note to the trash
+  ?              Show every key
+  q              Quit
+
+Examples:
+  snip tui                 # Browse the current notebook
+  snip tui --notebook work # Browse another notebook`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runTUI(); err != nil {
+			printError(err)
+		}
+	},
+}
+
+func runTUI() error {
+	noteRepo, tagRepo, err := getRepository()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	_, cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.WithNotebook(globalNotebook), handler.Quiet())
+
+	return tui.Run(tui.New(noteRepo, tagRepo, h, tui.Options{
+		Notebook:   globalNotebook.Name,
+		DateFormat: cfg.DateFormat,
+	}))
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +15,7 @@ func init() {
 		"",
 		"If you want to update the title, you can use this flag e.g. --title 'New Title'",
 	)
+	addPickFlag(updateCmd)
 }
 
 var updateCmd = &cobra.Command{
@@ -25,23 +24,44 @@ var updateCmd = &cobra.Command{
 	Long: `Update an existing note by opening your default editor to modify its content.
 You can also change the note's title using the --title flag.
 
-The note's content will open in your default editor where you can make changes.
-Save and close the editor to apply the updates. The modification timestamp
-will be automatically updated.
+The note opens in your default editor with its title, slug, tags and
+properties in a front matter block above the content:
+
+  ---
+  title: Sprint review
+  slug: sprint-review
+  tags: [work/meetings, team]
+  priority: 2
+  status: open
+  ---
+
+A snippet also has its language key (see 'snip create --help').
+
+Save and close the editor to apply every change at once. Leaving title, slug,
+tags or language out keeps their value; every other key is a property (see
+'snip prop'), so adding a line sets one and removing it unsets it. If the front matter is invalid, e.g. the slug is used by another
+note, the editor opens again with the error on top; empty the file to cancel.
+The modification timestamp will be automatically updated.
 
 Flags:
-  --title, -t    Update the note's title (optional)
+  --title, -t    Start the front matter with this title (optional)
+  --interactive, -i  Pick the note with a fuzzy finder, also used when no ID is given
 
 Examples:
   snip update 1                           # Edit content of note 1
   snip update 1 --title "New Title"      # Edit content and change title
-  snip update 42 -t "Updated Meeting"    # Edit note 42 with new title`,
-	Args: cobra.ExactArgs(1),
+  snip update 42 -t "Updated Meeting"    # Edit note 42 with new title
+  snip update meeting-notes              # Edit the note by its slug (see 'snip show --help')
+  snip update -i deploy                  # Pick a note matching "deploy" and edit it`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
-			return h.UpdateNote(args[0], title)
+			id, err := noteIDArg(args)
+			if err != nil {
+				return err
+			}
+			return h.UpdateNote(id, title)
 		}); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 		}
 	},
 }
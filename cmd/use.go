@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"maps"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var useSets []string
+var useVars []string
+var useWrite string
+var useRun bool
+var useYes bool
+
+func init() {
+	// the local --set shadows the persistent one that overrides settings, on
+	// this command it fills in placeholders
+	useCmd.Flags().StringArrayVar(&useSets, "set", nil, "Value of a placeholder as key=value (repeatable)")
+	useCmd.Flags().StringArrayVar(&useVars, "var", nil, "Same as --set, as in 'snip create --template'")
+	useCmd.Flags().StringVarP(&useWrite, "write", "w", "", "Write the expanded text to this file instead of printing it")
+	useCmd.Flags().BoolVarP(&useRun, "run", "r", false, "Run the expanded text with $SHELL after confirmation")
+	useCmd.Flags().BoolVarP(&useYes, "yes", "y", false, "With --run, run without asking")
+	addPickFlag(useCmd)
+}
+
+var useCmd = &cobra.Command{
+	Use:   "use [id]",
+	Short: "Fill in the placeholders of a snippet and print, save or run it",
+	Long: `Use a note as a reusable command or text snippet.
+
+Placeholders are written {{name}}, or {{name:default}} with a default value.
+Values come from --set, the others are asked for, the default being used when
+the answer is empty. Without a terminal to ask on, a placeholder with no value
+and no default is an error. The expanded text is printed on stdout and the
+questions on stderr, so it can be piped.
+
+Here --set fills in placeholders rather than overriding a setting, use the
+SNIP_* environment variables for that.
+
+Flags:
+  --set          Value of a placeholder as key=value (repeatable)
+  --var          Same as --set
+  --write, -w    Write the expanded text to a file instead of printing it
+  --run, -r      Show the expanded text and run it with $SHELL once confirmed
+  --yes, -y      With --run, do not ask for confirmation
+  --interactive, -i  Pick the note with a fuzzy finder, also used when no ID is given
+
+Examples:
+  # note 42: kubectl -n {{namespace}} rollout restart deploy/{{name:api}}
+  snip use 42                                  # Asks for namespace and name
+  snip use 42 --set namespace=prod             # Asks for name only, api by default
+  snip use 42 --set namespace=prod | pbcopy    # Copy the command, name is api
+  snip use 42 --set namespace=prod --run       # Run it after confirmation
+  snip use nginx-conf -w /tmp/nginx.conf       # Fill in a config file`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			if useYes && !useRun {
+				return errors.New("--yes needs --run")
+			}
+
+			vars, err := parseVars("var", useVars)
+			if err != nil {
+				return err
+			}
+			sets, err := parseVars("set", useSets)
+			if err != nil {
+				return err
+			}
+			maps.Copy(vars, sets)
+
+			id, err := noteIDArg(args)
+			if err != nil {
+				return err
+			}
+			return h.UseNote(id, vars, useWrite, useRun, useYes)
+		}); err != nil {
+			printError(err)
+		}
+	},
+}
@@ -0,0 +1,396 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source tells where the value of a setting came from. Later sources win.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Config holds the user settings of snip. Every field has a compiled-in
+// default that ~/.snip/config.yaml, environment variables and --set flags
+// override, in that order.
+type Config struct {
+	DateFormat    string
+	LineLimit     int
+	RowsLimit     int
+	MarkdownWidth int
+	// ExportDir replaces the export directory of the notebook when set.
+	ExportDir string
+	// Editor is used to edit notes. When empty it is detected from Editors.
+	Editor string
+	// Editors is the detection order for the editor. When empty a platform
+	// specific order is used.
+	Editors      []string
+	HistoryLimit int
+	// JournalTemplate is the Go template that new journal notes start from.
+	JournalTemplate string
+
+	sources map[string]Source
+}
+
+type field struct {
+	key         string
+	env         []string
+	description string
+	get         func(c *Config) any
+	set         func(c *Config, value string) error
+}
+
+var fields = []field{
+	{
+		key:         "date_format",
+		env:         []string{"SNIP_DATE_FORMAT"},
+		description: "Go layout used to print dates",
+		get:         func(c *Config) any { return c.DateFormat },
+		set:         func(c *Config, v string) error { return setString(&c.DateFormat, v, false) },
+	},
+	{
+		key:         "line_limit",
+		env:         []string{"SNIP_LINE_LIMIT"},
+		description: "Width that note previews are wrapped at",
+		get:         func(c *Config) any { return c.LineLimit },
+		set:         func(c *Config, v string) error { return setInt(&c.LineLimit, v, 1) },
+	},
+	{
+		key:         "rows_limit",
+		env:         []string{"SNIP_ROWS_LIMIT"},
+		description: "Lines shown in note previews",
+		get:         func(c *Config) any { return c.RowsLimit },
+		set:         func(c *Config, v string) error { return setInt(&c.RowsLimit, v, 1) },
+	},
+	{
+		key:         "markdown_width",
+		env:         []string{"SNIP_MARKDOWN_WIDTH"},
+		description: "Width of rendered markdown",
+		get:         func(c *Config) any { return c.MarkdownWidth },
+		set:         func(c *Config, v string) error { return setInt(&c.MarkdownWidth, v, 1) },
+	},
+	{
+		key:         "export_dir",
+		env:         []string{"SNIP_EXPORT_DIR"},
+		description: "Directory for exports, the notebook's export directory when empty",
+		get:         func(c *Config) any { return c.ExportDir },
+		set:         func(c *Config, v string) error { return setString(&c.ExportDir, expandHome(v), true) },
+	},
+	{
+		key:         "editor",
+		env:         []string{"SNIP_EDITOR", "EDITOR"},
+		description: "Editor command, detected when empty",
+		get:         func(c *Config) any { return c.Editor },
+		set:         func(c *Config, v string) error { return setString(&c.Editor, v, true) },
+	},
+	{
+		key:         "editors",
+		env:         []string{"SNIP_EDITORS"},
+		description: "Comma separated editor detection order",
+		get:         func(c *Config) any { return c.Editors },
+		set:         func(c *Config, v string) error { c.Editors = splitList(v); return nil },
+	},
+	{
+		key:         "history_limit",
+		env:         []string{"SNIP_HISTORY_LIMIT"},
+		description: "Revisions kept per note, 0 keeps all",
+		get:         func(c *Config) any { return c.HistoryLimit },
+		set:         func(c *Config, v string) error { return setInt(&c.HistoryLimit, v, 0) },
+	},
+	{
+		key:         "journal_template",
+		env:         []string{"SNIP_JOURNAL_TEMPLATE"},
+		description: "Go template new journal notes start from, .Date is the day",
+		get:         func(c *Config) any { return c.JournalTemplate },
+		set:         func(c *Config, v string) error { return setString(&c.JournalTemplate, v, true) },
+	},
+}
+
+func Default() *Config {
+	return &Config{
+		DateFormat:      "2006-01-02 15:04:05",
+		LineLimit:       62,
+		RowsLimit:       4,
+		MarkdownWidth:   100,
+		HistoryLimit:    100,
+		JournalTemplate: `# {{date .Date "Monday, January 2, 2006"}}\n\n`,
+		sources:         map[string]Source{},
+	}
+}
+
+// Path returns the config file to use: SNIP_CONFIG when set, so a team can
+// point every machine to a shared file, otherwise ~/.snip/config.yaml.
+func Path() (string, error) {
+	if path := os.Getenv("SNIP_CONFIG"); path != "" {
+		return expandHome(path), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, ".snip", "config.yaml"), nil
+}
+
+// Load builds the configuration from the defaults, the file at path (which may
+// not exist), the environment and overrides given as key=value.
+func Load(path string, overrides []string) (*Config, error) {
+	c := Default()
+
+	values, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range sortedKeys(values) {
+		if err := c.apply(key, values[key], SourceFile); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	for _, f := range fields {
+		for _, env := range f.env {
+			if value, ok := os.LookupEnv(env); ok && value != "" {
+				if err := c.apply(f.key, value, SourceEnv); err != nil {
+					return nil, fmt.Errorf("%s: %w", env, err)
+				}
+				break
+			}
+		}
+	}
+
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --set value '%s', expected key=value", override)
+		}
+		if err := c.apply(strings.TrimSpace(key), value, SourceFlag); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Keys returns every setting name in display order.
+func Keys() []string {
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = f.key
+	}
+	return keys
+}
+
+func lookup(key string) (field, error) {
+	for _, f := range fields {
+		if f.key == key {
+			return f, nil
+		}
+	}
+	return field{}, fmt.Errorf("unknown config key '%s' (use one of %s)", key, strings.Join(Keys(), ", "))
+}
+
+func (c *Config) apply(key, value string, source Source) error {
+	f, err := lookup(key)
+	if err != nil {
+		return err
+	}
+	if err := f.set(c, value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	c.sources[key] = source
+	return nil
+}
+
+// Get returns a setting formatted as it would be written with Set.
+func (c *Config) Get(key string) (string, error) {
+	f, err := lookup(key)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := f.get(c).(type) {
+	case []string:
+		return strings.Join(v, ","), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func (c *Config) Set(key, value string) error {
+	return c.apply(key, value, SourceFlag)
+}
+
+func (c *Config) Source(key string) Source {
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+// Description returns the help text of a setting.
+func Description(key string) string {
+	f, err := lookup(key)
+	if err != nil {
+		return ""
+	}
+	return f.description
+}
+
+// SetInFile validates value and writes it to the config file at path, keeping
+// the other settings and comments of the file.
+func SetInFile(path, key, value string) error {
+	f, err := lookup(key)
+	if err != nil {
+		return err
+	}
+
+	c := Default()
+	if err := f.set(c, value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s must be a mapping of settings", path)
+	}
+
+	var valueNode yaml.Node
+	if err := valueNode.Encode(f.get(c)); err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = &valueNode
+			replaced = true
+		}
+	}
+	if !replaced {
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+		mapping.Content = append(mapping.Content, keyNode, &valueNode)
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	return os.WriteFile(path, out, 0644)
+}
+
+// readFile returns the settings of the config file as strings. A missing file
+// has no settings.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	raw := map[string]any{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			values[key] = ""
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+
+	return values, nil
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func setString(target *string, value string, allowEmpty bool) error {
+	value = strings.TrimSpace(value)
+	if value == "" && !allowEmpty {
+		return errors.New("value cannot be empty")
+	}
+	*target = value
+	return nil
+}
+
+func setInt(target *int, value string, min int) error {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("'%s' is not a number", value)
+	}
+	if n < min {
+		return fmt.Errorf("must be at least %d", min)
+	}
+	*target = n
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,592 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/link"
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+// Migration is a single, ordered step of the schema. Once released a migration
+// must never change: fix mistakes by appending a new one.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(tx *sql.Tx) error
+}
+
+type MigrationStatus struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at"`
+}
+
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "initial schema",
+		Up:          execScript(initialSchema),
+	},
+	{
+		Version:     2,
+		Description: "full-text search on fts5 with external content",
+		Up:          migrateToFTS5,
+	},
+	{
+		Version:     3,
+		Description: "note revision history",
+		Up:          execScript(revisionsSchema),
+	},
+	{
+		Version:     4,
+		Description: "trash for deleted notes",
+		Up:          execScript(trashSchema),
+	},
+	{
+		Version:     5,
+		Description: "case-insensitive unique tag names",
+		Up:          execScript(uniqueTagsSchema),
+	},
+	{
+		Version:     6,
+		Description: "hierarchical tags",
+		Up:          migrateTagTree,
+	},
+	{
+		Version:     7,
+		Description: "links between notes",
+		Up:          migrateNoteLinks,
+	},
+	{
+		Version:     8,
+		Description: "unique note slugs",
+		Up:          migrateNoteSlugs,
+	},
+	{
+		Version:     9,
+		Description: "one journal note per day",
+		Up:          execScript(journalSchema),
+	},
+	{
+		Version:     10,
+		Description: "typed note properties",
+		Up:          execScript(propertiesSchema),
+	},
+	{
+		Version:     11,
+		Description: "code language of notes",
+		Up:          execScript(languageSchema),
+	},
+	{
+		Version:     12,
+		Description: "drop properties of deleted notes",
+		Up:          execScript(propertiesCleanupSchema),
+	},
+}
+
+// Databases created before migrations existed already hold this schema, so every
+// statement is guarded with IF NOT EXISTS and they upgrade in place.
+const initialSchema = `
+    -- Main Table
+    CREATE TABLE IF NOT EXISTS notes (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        title TEXT NOT NULL,
+        content TEXT NOT NULL,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+    );
+
+    CREATE TABLE IF NOT EXISTS tags (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        name TEXT NOT NULL
+    );
+
+    CREATE TABLE IF NOT EXISTS notes_tags (
+        note_id INTEGER NOT NULL,
+        tag_id INTEGER NOT NULL,
+        PRIMARY KEY (note_id, tag_id),
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
+        FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+    );
+
+    -- Index
+    CREATE INDEX IF NOT EXISTS idx_notes_title ON notes(title);
+    CREATE INDEX IF NOT EXISTS idx_notes_created_at ON notes(created_at);
+
+    -- FTS Table
+    CREATE VIRTUAL TABLE IF NOT EXISTS notes_fts USING fts4(id, title, content);
+
+    -- Populate FTS table with existing data (only if empty)
+    INSERT OR IGNORE INTO notes_fts(id, title, content)
+    SELECT id, title, content FROM notes
+    WHERE id NOT IN (SELECT id FROM notes_fts);
+
+    -- Triggers
+    CREATE TRIGGER IF NOT EXISTS notes_fts_ai AFTER INSERT ON notes BEGIN
+        INSERT INTO notes_fts(id, title, content) VALUES (new.id, new.title, new.content);
+    END;
+
+    CREATE TRIGGER IF NOT EXISTS notes_fts_au AFTER UPDATE ON notes BEGIN
+        UPDATE notes_fts SET title = new.title, content = new.content WHERE id = old.id;
+    END;
+
+    CREATE TRIGGER IF NOT EXISTS notes_fts_ad AFTER DELETE ON notes BEGIN
+        DELETE FROM notes_fts WHERE id = old.id;
+    END;
+`
+
+// ErrFTS5Unavailable is returned when the sqlite driver was compiled without
+// FTS5. Build with `-tags sqlite_fts5` (see the Makefile).
+var ErrFTS5Unavailable = errors.New("sqlite was built without FTS5 support, rebuild snip with -tags sqlite_fts5")
+
+// The fts4 table kept its own copy of every note. The fts5 table reads title and
+// content straight from notes, so the triggers only maintain the index.
+const fts5Schema = `
+    DROP TRIGGER IF EXISTS notes_fts_ai;
+    DROP TRIGGER IF EXISTS notes_fts_au;
+    DROP TRIGGER IF EXISTS notes_fts_ad;
+    DROP TABLE IF EXISTS notes_fts;
+
+    CREATE VIRTUAL TABLE notes_fts USING fts5(
+        title,
+        content,
+        content='notes',
+        content_rowid='id',
+        tokenize='porter unicode61'
+    );
+
+    INSERT INTO notes_fts(notes_fts) VALUES ('rebuild');
+
+    CREATE TRIGGER notes_fts_ai AFTER INSERT ON notes BEGIN
+        INSERT INTO notes_fts(rowid, title, content) VALUES (new.id, new.title, new.content);
+    END;
+
+    CREATE TRIGGER notes_fts_ad AFTER DELETE ON notes BEGIN
+        INSERT INTO notes_fts(notes_fts, rowid, title, content) VALUES ('delete', old.id, old.title, old.content);
+    END;
+
+    CREATE TRIGGER notes_fts_au AFTER UPDATE OF title, content ON notes BEGIN
+        INSERT INTO notes_fts(notes_fts, rowid, title, content) VALUES ('delete', old.id, old.title, old.content);
+        INSERT INTO notes_fts(rowid, title, content) VALUES (new.id, new.title, new.content);
+    END;
+`
+
+// Every existing note gets its current state as revision 1, so the first edit
+// after upgrading can already be diffed and reverted.
+const revisionsSchema = `
+    CREATE TABLE note_revisions (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        note_id INTEGER NOT NULL,
+        revision INTEGER NOT NULL,
+        title TEXT NOT NULL,
+        content TEXT NOT NULL,
+        tags TEXT NOT NULL DEFAULT '',
+        created_at DATETIME NOT NULL,
+        UNIQUE (note_id, revision),
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+    );
+
+    INSERT INTO note_revisions (note_id, revision, title, content, tags, created_at)
+    SELECT n.id, 1, n.title, n.content,
+        COALESCE((
+            SELECT GROUP_CONCAT(name) FROM (
+                SELECT t.name FROM notes_tags nt
+                INNER JOIN tags t ON t.id = nt.tag_id
+                WHERE nt.note_id = n.id
+                ORDER BY t.name
+            )
+        ), ''),
+        n.updated_at
+    FROM notes n;
+
+    -- foreign keys are not enforced on every connection, so clean up explicitly
+    CREATE TRIGGER note_revisions_ad AFTER DELETE ON notes BEGIN
+        DELETE FROM note_revisions WHERE note_id = old.id;
+    END;
+`
+
+// Notes in the trash keep their row with deleted_at set. Tag links are removed
+// by hand on purge because foreign keys are not enforced on every connection.
+const trashSchema = `
+    ALTER TABLE notes ADD COLUMN deleted_at DATETIME;
+
+    CREATE INDEX idx_notes_deleted_at ON notes(deleted_at);
+
+    CREATE TRIGGER notes_tags_ad AFTER DELETE ON notes BEGIN
+        DELETE FROM notes_tags WHERE note_id = old.id;
+    END;
+`
+
+// Tags that only differ in case ("Work" and "work") are merged into the oldest
+// one before the table is rebuilt with a NOCASE unique name.
+const uniqueTagsSchema = `
+    DELETE FROM notes_tags WHERE tag_id IN (SELECT id FROM tags WHERE trim(name) = '');
+    DELETE FROM tags WHERE trim(name) = '';
+
+    INSERT OR IGNORE INTO notes_tags (note_id, tag_id)
+    SELECT nt.note_id, (SELECT MIN(t2.id) FROM tags t2 WHERE t2.name = t.name COLLATE NOCASE)
+    FROM notes_tags nt
+    INNER JOIN tags t ON t.id = nt.tag_id;
+
+    DELETE FROM notes_tags WHERE tag_id NOT IN (SELECT MIN(id) FROM tags GROUP BY name COLLATE NOCASE);
+    DELETE FROM tags WHERE id NOT IN (SELECT MIN(id) FROM tags GROUP BY name COLLATE NOCASE);
+
+    CREATE TABLE tags_new (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        name TEXT NOT NULL COLLATE NOCASE UNIQUE
+    );
+    INSERT INTO tags_new (id, name) SELECT id, name FROM tags;
+    DROP TABLE tags;
+    ALTER TABLE tags_new RENAME TO tags;
+
+    CREATE TRIGGER tags_ad AFTER DELETE ON tags BEGIN
+        DELETE FROM notes_tags WHERE tag_id = old.id;
+    END;
+`
+
+// Tags keep their full path ("work/infra/k8s") as name and point at their parent
+// ("work/infra"). Missing ancestors of existing nested names are created.
+func migrateTagTree(tx *sql.Tx) error {
+	schema := `
+        ALTER TABLE tags ADD COLUMN parent_id INTEGER REFERENCES tags(id);
+        CREATE INDEX idx_tags_parent_id ON tags(parent_id);
+    `
+	if _, err := tx.Exec(schema); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`SELECT name FROM tags WHERE name LIKE '%/%'`)
+	if err != nil {
+		return err
+	}
+
+	var nested []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		nested = append(nested, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, name := range nested {
+		if _, err := ensureTagPath(tx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureTagPath makes sure the tag and all its ancestors exist and are linked,
+// returning the id of the tag.
+func ensureTagPath(tx *sql.Tx, path string) (int64, error) {
+	var parentID sql.NullInt64
+	if i := strings.LastIndex(path, "/"); i > 0 {
+		id, err := ensureTagPath(tx, path[:i])
+		if err != nil {
+			return 0, err
+		}
+		parentID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	var id int64
+	err := tx.QueryRow(`SELECT id FROM tags WHERE name = ?`, path).Scan(&id)
+	if err == sql.ErrNoRows {
+		result, err := tx.Exec(`INSERT INTO tags (name, parent_id) VALUES (?, ?)`, path, parentID)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`UPDATE tags SET parent_id = ? WHERE id = ?`, parentID, id)
+	return id, err
+}
+
+// Links are stored as written. Title references are resolved when read, through
+// the resolved_links view, so creating or renaming a note fixes or breaks them
+// without touching note_links.
+const noteLinksSchema = `
+    CREATE TABLE note_links (
+        source_id INTEGER NOT NULL,
+        ref TEXT NOT NULL COLLATE NOCASE,
+        target_id INTEGER,
+        PRIMARY KEY (source_id, ref),
+        FOREIGN KEY (source_id) REFERENCES notes(id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX idx_note_links_ref ON note_links(ref);
+
+    CREATE VIEW resolved_links AS
+    SELECT l.source_id, l.ref,
+        CASE WHEN l.target_id IS NOT NULL
+            THEN (SELECT n.id FROM notes n WHERE n.id = l.target_id AND n.deleted_at IS NULL)
+            ELSE (SELECT MIN(n.id) FROM notes n WHERE n.title = l.ref COLLATE NOCASE AND n.deleted_at IS NULL)
+        END AS target_id
+    FROM note_links l;
+
+    CREATE TRIGGER note_links_ad AFTER DELETE ON notes BEGIN
+        DELETE FROM note_links WHERE source_id = old.id;
+    END;
+`
+
+func migrateNoteLinks(tx *sql.Tx) error {
+	if _, err := tx.Exec(noteLinksSchema); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`SELECT id, content FROM notes WHERE content LIKE '%[[%]]%'`)
+	if err != nil {
+		return err
+	}
+
+	contents := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var content string
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return err
+		}
+		contents[id] = content
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, content := range contents {
+		for _, ref := range link.Parse(content) {
+			query := `INSERT INTO note_links (source_id, ref, target_id) VALUES (?, ?, NULLIF(?, 0))`
+			if _, err := tx.Exec(query, id, ref.Text, ref.NoteID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// journal_date is NULL for regular notes, so only journal notes compete for a
+// date.
+const journalSchema = `
+    ALTER TABLE notes ADD COLUMN journal_date TEXT;
+
+    CREATE UNIQUE INDEX idx_notes_journal_date ON notes(journal_date);
+`
+
+// Values are stored as text, type tells how to read them back and compare them.
+const propertiesSchema = `
+    CREATE TABLE note_properties (
+        note_id INTEGER NOT NULL,
+        key TEXT NOT NULL,
+        type TEXT NOT NULL,
+        value TEXT NOT NULL,
+        PRIMARY KEY (note_id, key),
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+    );
+`
+
+// language is empty for notes that are not code.
+const languageSchema = `
+    ALTER TABLE notes ADD COLUMN language TEXT NOT NULL DEFAULT '';
+`
+
+// Foreign keys are off, so the cascade of note_properties never fires. Like the
+// other tables keyed by note, a trigger cleans up instead, after removing the
+// rows left behind so far.
+const propertiesCleanupSchema = `
+    DELETE FROM note_properties WHERE note_id NOT IN (SELECT id FROM notes);
+
+    CREATE TRIGGER note_properties_ad AFTER DELETE ON notes BEGIN
+        DELETE FROM note_properties WHERE note_id = old.id;
+    END;
+`
+
+// Slugs are given in id order, so when titles collide the oldest note keeps the
+// plain slug.
+func migrateNoteSlugs(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE notes ADD COLUMN slug TEXT`); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`SELECT id, title FROM notes ORDER BY id`)
+	if err != nil {
+		return err
+	}
+
+	slugs := make(map[int]string)
+	used := make(map[string]bool)
+	var ids []int
+	for rows.Next() {
+		var id int
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			rows.Close()
+			return err
+		}
+
+		base := note.Slugify(title)
+		slug := base
+		for n := 2; used[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[slug] = true
+		slugs[id] = slug
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := tx.Exec(`UPDATE notes SET slug = ? WHERE id = ?`, slugs[id], id); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_notes_slug ON notes(slug)`)
+	return err
+}
+
+func migrateToFTS5(tx *sql.Tx) error {
+	var enabled bool
+	if err := tx.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&enabled); err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrFTS5Unavailable
+	}
+
+	_, err := tx.Exec(fts5Schema)
+	return err
+}
+
+func execScript(script string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(script)
+		return err
+	}
+}
+
+func ensureVersionTable(db *sql.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			description TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		)
+	`
+
+	_, err := db.Exec(query)
+	return err
+}
+
+func appliedVersions(db *sql.DB) (map[int]time.Time, error) {
+	if err := ensureVersionTable(db); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`SELECT version, applied_at FROM schema_version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// Migrate applies every pending migration in order, each one in its own
+// transaction, and returns the migrations that were applied.
+func Migrate(db *sql.DB) ([]Migration, error) {
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	var done []Migration
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		if err := runMigration(db, m); err != nil {
+			return done, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+		done = append(done, m)
+	}
+
+	return done, nil
+}
+
+func runMigration(db *sql.DB, m Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.Up(tx); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO schema_version (version, description, applied_at) VALUES (?, ?, ?)`
+	if _, err := tx.Exec(query, m.Version, m.Description, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Status reports every known migration and whether it has been applied.
+func Status(db *sql.DB) ([]MigrationStatus, error) {
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		status := MigrationStatus{Version: m.Version, Description: m.Description}
+		if appliedAt, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Latest returns the schema version the binary expects.
+func Latest() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
@@ -0,0 +1,9 @@
+//go:build !sqlite_fts5
+
+package database
+
+// snip searches with FTS5, which go-sqlite3 only compiles in with the
+// sqlite_fts5 tag. Without it every command would fail at migration, so the
+// build fails instead: use `make build` or pass -tags sqlite_fts5 to go build,
+// go test and go install.
+var _ = snip_must_be_built_with_tags_sqlite_fts5
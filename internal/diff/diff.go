@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines computes a line diff of a and b from their longest common subsequence.
+func Lines(a, b []string) []Line {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []Line
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Delete, a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Insert, b[j]})
+	}
+
+	return lines
+}
+
+// Unified renders the difference between a and b in unified diff format with
+// the given number of context lines. It returns "" when both are equal.
+func Unified(fromName, toName, a, b string, context int) string {
+	lines := Lines(splitLines(a), splitLines(b))
+
+	changed := false
+	for _, l := range lines {
+		if l.Op != Equal {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n", fromName)
+	fmt.Fprintf(&out, "+++ %s\n", toName)
+
+	for _, h := range hunks(lines, context) {
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(h.fromStart, h.fromCount), hunkRange(h.toStart, h.toCount))
+		for _, l := range lines[h.start:h.end] {
+			switch l.Op {
+			case Equal:
+				out.WriteString(" " + l.Text + "\n")
+			case Delete:
+				out.WriteString("-" + l.Text + "\n")
+			case Insert:
+				out.WriteString("+" + l.Text + "\n")
+			}
+		}
+	}
+
+	return out.String()
+}
+
+type hunk struct {
+	start, end           int
+	fromStart, fromCount int
+	toStart, toCount     int
+}
+
+// hunks groups changed lines with up to context unchanged lines around them,
+// merging groups whose context would overlap.
+func hunks(lines []Line, context int) []hunk {
+	var result []hunk
+
+	i := 0
+	for i < len(lines) {
+		if lines[i].Op == Equal {
+			i++
+			continue
+		}
+
+		start := max(i-context, 0)
+		end := i
+		for end < len(lines) {
+			if lines[end].Op != Equal {
+				end++
+				continue
+			}
+
+			run := end
+			for run < len(lines) && lines[run].Op == Equal {
+				run++
+			}
+			if run == len(lines) || run-end > 2*context {
+				end = min(end+context, len(lines))
+				break
+			}
+			end = run
+		}
+
+		result = append(result, newHunk(lines, start, end))
+		i = end
+	}
+
+	return result
+}
+
+func newHunk(lines []Line, start, end int) hunk {
+	h := hunk{start: start, end: end, fromStart: 1, toStart: 1}
+
+	for _, l := range lines[:start] {
+		if l.Op != Insert {
+			h.fromStart++
+		}
+		if l.Op != Delete {
+			h.toStart++
+		}
+	}
+	for _, l := range lines[start:end] {
+		if l.Op != Insert {
+			h.fromCount++
+		}
+		if l.Op != Delete {
+			h.toCount++
+		}
+	}
+
+	return h
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
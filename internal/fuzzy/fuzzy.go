@@ -0,0 +1,151 @@
+package fuzzy
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Scores follow the usual fuzzy finder rules: every matched character earns
+// points, gaps cost points, and characters starting a word or following the
+// previous match earn a bonus.
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+	bonusBoundary     = 8
+	bonusCamelCase    = 7
+	bonusConsecutive  = 4
+	// the first pattern character counts its bonus twice
+	bonusFirstCharMultiplier = 2
+)
+
+// MaxTextLength caps how much of a text is scored, so long notes stay fast.
+const MaxTextLength = 4096
+
+const noMatch = math.MinInt32
+
+// Match is where and how well a pattern matched a text. Positions are rune
+// indexes into the text.
+type Match struct {
+	Score     int
+	Positions []int
+}
+
+// Score finds the best alignment of pattern in text, ignoring case. Every rune
+// of pattern must appear in text in order, ok is false otherwise.
+func Score(pattern, text string) (Match, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(text)
+	if len(t) > MaxTextLength {
+		t = t[:MaxTextLength]
+	}
+	if len(p) == 0 {
+		return Match{}, true
+	}
+	if len(p) > len(t) || !contains(p, t) {
+		return Match{}, false
+	}
+
+	lower := make([]rune, len(t))
+	bonus := make([]int, len(t))
+	for j, r := range t {
+		lower[j] = unicode.ToLower(r)
+		bonus[j] = charBonus(t, j)
+	}
+
+	// scores[i][j] is the best score with p[i] matched at t[j], from[i][j] the
+	// position p[i-1] was matched at
+	scores := make([][]int, len(p))
+	from := make([][]int, len(p))
+	for i := range p {
+		scores[i] = make([]int, len(t))
+		from[i] = make([]int, len(t))
+
+		gap, gapFrom := noMatch, -1
+		for j := range t {
+			scores[i][j] = noMatch
+
+			if lower[j] == p[i] {
+				switch {
+				case i == 0:
+					scores[i][j] = scoreMatch + bonus[j]*bonusFirstCharMultiplier
+				default:
+					if gap != noMatch {
+						scores[i][j] = gap + scoreMatch + bonus[j]
+						from[i][j] = gapFrom
+					}
+					if j > 0 && scores[i-1][j-1] != noMatch {
+						consecutive := scores[i-1][j-1] + scoreMatch + max(bonus[j], bonusConsecutive)
+						if consecutive >= scores[i][j] {
+							scores[i][j] = consecutive
+							from[i][j] = j - 1
+						}
+					}
+				}
+			}
+
+			// the best earlier match of p[i-1] to continue from after a gap
+			if i > 0 {
+				if gap != noMatch {
+					gap += scoreGapExtension
+				}
+				if scores[i-1][j] != noMatch && scores[i-1][j]+scoreGapStart > gap {
+					gap, gapFrom = scores[i-1][j]+scoreGapStart, j
+				}
+			}
+		}
+	}
+
+	last := len(p) - 1
+	best, end := noMatch, -1
+	for j, s := range scores[last] {
+		if s > best {
+			best, end = s, j
+		}
+	}
+	if best == noMatch {
+		return Match{}, false
+	}
+
+	positions := make([]int, len(p))
+	for i, j := last, end; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+
+	return Match{Score: best, Positions: positions}, true
+}
+
+// contains reports whether the runes of pattern appear in text in order, a
+// cheap check before scoring.
+func contains(pattern, text []rune) bool {
+	i := 0
+	for _, r := range text {
+		if i < len(pattern) && unicode.ToLower(r) == pattern[i] {
+			i++
+		}
+	}
+	return i == len(pattern)
+}
+
+func charBonus(text []rune, j int) int {
+	if j == 0 {
+		return bonusBoundary
+	}
+
+	prev, cur := text[j-1], text[j]
+	switch {
+	case !isWordRune(prev) && isWordRune(cur):
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return bonusCamelCase
+	case !unicode.IsDigit(prev) && unicode.IsDigit(cur):
+		return bonusCamelCase
+	}
+	return 0
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,66 @@
+package fuzzy
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+// titleWeight makes a word found in the title outrank the same word in the
+// tags or content.
+const titleWeight = 2
+
+// NoteMatch is a note matching a query, with the title runes to highlight.
+type NoteMatch struct {
+	Note           *note.NoteWithTags
+	Score          int
+	TitlePositions []int
+}
+
+// RankNotes keeps the notes where every word of query matches the title, the
+// tags or the content, best matches first. Ties and an empty query keep the
+// order of notes.
+func RankNotes(notes []*note.NoteWithTags, query string) []NoteMatch {
+	words := strings.Fields(query)
+
+	var matches []NoteMatch
+	for _, n := range notes {
+		match, ok := matchNote(n, words)
+		if ok {
+			matches = append(matches, match)
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b NoteMatch) int {
+		return b.Score - a.Score
+	})
+	return matches
+}
+
+func matchNote(n *note.NoteWithTags, words []string) (NoteMatch, bool) {
+	result := NoteMatch{Note: n}
+	tags := strings.Join(n.Tags, " ")
+
+	for _, word := range words {
+		best, found := 0, false
+
+		if m, ok := Score(word, n.Title); ok {
+			best, found = m.Score*titleWeight, true
+			result.TitlePositions = append(result.TitlePositions, m.Positions...)
+		}
+		if m, ok := Score(word, tags); ok && (!found || m.Score > best) {
+			best, found = m.Score, true
+		}
+		if m, ok := Score(word, n.Content); ok && (!found || m.Score > best) {
+			best, found = m.Score, true
+		}
+
+		if !found {
+			return NoteMatch{}, false
+		}
+		result.Score += best
+	}
+
+	return result, true
+}
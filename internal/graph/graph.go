@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/link"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/tag"
+)
+
+const (
+	NodeNote = "note"
+	NodeTag  = "tag"
+
+	// EdgeLink goes from a note to the note it links to.
+	EdgeLink = "link"
+	// EdgeTag goes from a note to one of its tags.
+	EdgeTag = "tag"
+	// EdgeParent goes from a tag to its parent tag.
+	EdgeParent = "parent"
+)
+
+type Node struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	Label     string     `json:"label"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type Edge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+func noteNodeID(id int) string {
+	return fmt.Sprintf("note:%d", id)
+}
+
+func tagNodeID(id int) string {
+	return fmt.Sprintf("tag:%d", id)
+}
+
+// Build connects the given notes through their links and tags. Tags are kept
+// when one of the notes uses them or one of their subtags, and links only when
+// both ends are part of notes. Broken links are left out.
+func Build(notes []*note.NoteWithTags, tags []*tag.Tag, links []*link.Link) *Graph {
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}}
+
+	tagsByName := make(map[string]*tag.Tag, len(tags))
+	tagsByID := make(map[int]*tag.Tag, len(tags))
+	for _, t := range tags {
+		tagsByName[strings.ToLower(t.Name)] = t
+		tagsByID[t.ID] = t
+	}
+
+	included := make(map[int]bool, len(notes))
+	usedTags := make(map[int]bool)
+
+	for _, n := range notes {
+		createdAt := n.CreatedAt
+		g.Nodes = append(g.Nodes, Node{
+			ID:        noteNodeID(n.ID),
+			Type:      NodeNote,
+			Label:     fmt.Sprintf("#%d %s", n.ID, n.Title),
+			CreatedAt: &createdAt,
+		})
+		included[n.ID] = true
+
+		for _, name := range n.Tags {
+			t, ok := tagsByName[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+			g.Edges = append(g.Edges, Edge{Source: noteNodeID(n.ID), Target: tagNodeID(t.ID), Type: EdgeTag})
+
+			for ; t != nil && !usedTags[t.ID]; t = tagsByID[t.ParentID] {
+				usedTags[t.ID] = true
+			}
+		}
+	}
+
+	for _, t := range tags {
+		if !usedTags[t.ID] {
+			continue
+		}
+		g.Nodes = append(g.Nodes, Node{ID: tagNodeID(t.ID), Type: NodeTag, Label: t.Name})
+		if t.ParentID != 0 {
+			g.Edges = append(g.Edges, Edge{Source: tagNodeID(t.ID), Target: tagNodeID(t.ParentID), Type: EdgeParent})
+		}
+	}
+
+	seen := make(map[Edge]bool)
+	for _, l := range links {
+		if l.IsBroken() || !included[l.SourceID] || !included[l.TargetID] {
+			continue
+		}
+		edge := Edge{Source: noteNodeID(l.SourceID), Target: noteNodeID(l.TargetID), Type: EdgeLink}
+		if !seen[edge] {
+			seen[edge] = true
+			g.Edges = append(g.Edges, edge)
+		}
+	}
+
+	return g
+}
+
+func (g *Graph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g)
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Notes are drawn as
+// boxes, tags as ellipses, tag edges dashed and tag hierarchy edges dotted.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("digraph snip {\n")
+	b.WriteString("  rankdir=LR;\n")
+
+	for _, n := range g.Nodes {
+		shape := "box"
+		if n.Type == NodeTag {
+			shape = "ellipse"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", dotQuote(n.ID), dotQuote(n.Label), shape)
+	}
+
+	for _, e := range g.Edges {
+		style := "solid"
+		switch e.Type {
+		case EdgeTag:
+			style = "dashed"
+		case EdgeParent:
+			style = "dotted"
+		}
+		fmt.Fprintf(&b, "  %s -> %s [style=%s];\n", dotQuote(e.Source), dotQuote(e.Target), style)
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/render"
+)
+
+type ConfigHandler struct {
+	path          string
+	cfg           *config.Config
+	editorHandler *EditorHandler
+	output
+}
+
+// setting is the structured form of a config value.
+type setting struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Source      string `json:"source"`
+	Description string `json:"description"`
+}
+
+// NewConfigHandler manages the config file at path. cfg is the configuration
+// in effect, with environment and flag overrides applied.
+func NewConfigHandler(path string, cfg *config.Config, out *render.Renderer) *ConfigHandler {
+	return &ConfigHandler{
+		path:          path,
+		cfg:           cfg,
+		editorHandler: NewEditorHandlerFromConfig(cfg),
+		output:        newOutput(out),
+	}
+}
+
+func (c *ConfigHandler) setting(key string) (setting, error) {
+	value, err := c.cfg.Get(key)
+	if err != nil {
+		return setting{}, err
+	}
+	return setting{Key: key, Value: value, Source: string(c.cfg.Source(key)), Description: config.Description(key)}, nil
+}
+
+func (c *ConfigHandler) GetConfig(key string) error {
+	s, err := c.setting(key)
+	if err != nil {
+		return err
+	}
+
+	if c.out.Structured() {
+		return c.out.Render(s)
+	}
+
+	fmt.Fprintln(c.out, s.Value)
+	return nil
+}
+
+func (c *ConfigHandler) SetConfig(key string, value string) error {
+	if err := config.SetInFile(c.path, key, value); err != nil {
+		return fmt.Errorf("failed to set %s: %w", key, err)
+	}
+
+	message := fmt.Sprintf("%s set to '%s'!", key, value)
+	if err := c.report(Result{Status: "set", Location: c.path, Message: message}, "✓ %s\n", message); err != nil {
+		return err
+	}
+
+	if source := c.cfg.Source(key); source == config.SourceEnv || source == config.SourceFlag {
+		c.printf("  Note: the %s value still overrides the config file.\n", source)
+	}
+	return nil
+}
+
+func (c *ConfigHandler) ListConfig() error {
+	var settings []setting
+	for _, key := range config.Keys() {
+		s, err := c.setting(key)
+		if err != nil {
+			return err
+		}
+		settings = append(settings, s)
+	}
+
+	if c.out.Structured() {
+		return c.out.Render(listOf(settings))
+	}
+
+	fmt.Fprintf(c.out, "┌─ Config file: %s\n", c.path)
+
+	for _, s := range settings {
+		value := s.Value
+		if value == "" {
+			value = "(empty)"
+		}
+
+		fmt.Fprintf(c.out, "  ├─ %s = %s [%s]\n", s.Key, value, s.Source)
+		fmt.Fprintf(c.out, "  │    %s\n", s.Description)
+	}
+
+	fmt.Fprintln(c.out, "└─ Precedence: flag > env > file > default")
+	return nil
+}
+
+// EditConfig opens the config file in the editor and checks it afterwards.
+func (c *ConfigHandler) EditConfig() error {
+	if _, err := os.Stat(c.path); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := os.WriteFile(c.path, []byte(configTemplate()), 0644); err != nil {
+			return fmt.Errorf("failed to create config file: %w", err)
+		}
+	}
+
+	if err := c.editorHandler.EditFile(c.path); err != nil {
+		return err
+	}
+
+	if _, err := config.Load(c.path, nil); err != nil {
+		return fmt.Errorf("config file saved but invalid: %w", err)
+	}
+
+	return c.report(Result{Status: "saved", Location: c.path, Message: "Config saved!"}, "✓ Config saved!\n")
+}
+
+// configTemplate lists every setting with its default, commented out.
+func configTemplate() string {
+	defaults := config.Default()
+
+	template := "# snip configuration, see 'snip config list'\n"
+	for _, key := range config.Keys() {
+		value, _ := defaults.Get(key)
+		template += fmt.Sprintf("\n# %s\n# %s: %q\n", config.Description(key), key, value)
+	}
+	return template
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/matheuzgomes/Snip/internal/database"
+	"github.com/matheuzgomes/Snip/internal/render"
+)
+
+type DatabaseHandler struct {
+	db         *sql.DB
+	dateFormat string
+	output
+}
+
+func NewDatabaseHandler(db *sql.DB, out *render.Renderer) *DatabaseHandler {
+	return &DatabaseHandler{
+		db:         db,
+		dateFormat: "2006-01-02 15:04:05",
+		output:     newOutput(out),
+	}
+}
+
+func (d *DatabaseHandler) Migrate() error {
+	applied, err := database.Migrate(d.db)
+	for _, m := range applied {
+		d.printf("✓ Applied migration %d: %s\n", m.Version, m.Description)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		message := fmt.Sprintf("Database is up to date (version %d).", database.Latest())
+		return d.report(Result{Status: "up_to_date", Count: count(0), Message: message}, "%s\n", message)
+	}
+
+	message := fmt.Sprintf("Database migrated to version %d!", database.Latest())
+	return d.report(Result{Status: "migrated", Count: count(len(applied)), Message: message}, "✓ %s\n", message)
+}
+
+func (d *DatabaseHandler) ShowMigrationStatus() error {
+	statuses, err := database.Status(d.db)
+	if err != nil {
+		return err
+	}
+
+	if d.out.Structured() {
+		return d.out.Render(listOf(statuses))
+	}
+
+	pending := 0
+	fmt.Fprintln(d.out, "┌─ Migrations:")
+	for _, s := range statuses {
+		if s.Applied {
+			fmt.Fprintf(d.out, "  ├─ [applied] %3d %s (%s)\n", s.Version, s.Description, s.AppliedAt.Format(d.dateFormat))
+		} else {
+			pending++
+			fmt.Fprintf(d.out, "  ├─ [pending] %3d %s\n", s.Version, s.Description)
+		}
+	}
+
+	fmt.Fprintf(d.out, "└─ %d pending, latest version %d\n", pending, database.Latest())
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+
+	"github.com/matheuzgomes/Snip/internal/config"
 )
 
 type EditorHandler struct {
@@ -17,6 +19,27 @@ func NewEditorHandler() *EditorHandler {
 	}
 }
 
+// NewEditorHandlerFromConfig uses the configured editor, or detects one in the
+// configured order.
+func NewEditorHandlerFromConfig(cfg *config.Config) *EditorHandler {
+	if cfg.Editor != "" {
+		return &EditorHandler{detectedEditor: cfg.Editor}
+	}
+
+	if len(cfg.Editors) > 0 {
+		if editor := os.Getenv("EDITOR"); editor != "" {
+			return &EditorHandler{detectedEditor: editor}
+		}
+		for _, editor := range cfg.Editors {
+			if isEditorAvailable(editor) {
+				return &EditorHandler{detectedEditor: editor}
+			}
+		}
+	}
+
+	return NewEditorHandler()
+}
+
 func (e *EditorHandler) HandleEditor(content string) (*os.File, error) {
 	tempFile, err := e.CreateTempFile()
 	if err != nil {
@@ -43,6 +66,21 @@ func (e *EditorHandler) HandleEditor(content string) (*os.File, error) {
 	return tempFile, nil
 }
 
+// EditFile opens an existing file in the editor and waits for it to close.
+func (e *EditorHandler) EditFile(path string) error {
+	editor, args := e.GetEditor()
+	cmd := exec.Command(editor, append(args, path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stdout
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to open editor: %w", err)
+	}
+
+	return nil
+}
+
 func detectEditor() string {
 	if editor := os.Getenv("EDITOR"); editor != "" {
 		return editor
@@ -210,6 +248,7 @@ func (e *EditorHandler) ShowEditorInfo() {
 	}
 
 	fmt.Println("\n┌─ Configuration:")
+	fmt.Println("├─ Run 'snip config set editor code' to choose an editor")
 	fmt.Println("├─ Set EDITOR environment variable to override")
 
 	switch runtime.GOOS {
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/language"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/property"
+	"github.com/matheuzgomes/Snip/internal/repository"
+	"github.com/matheuzgomes/Snip/internal/tag"
+	"gopkg.in/yaml.v3"
+)
+
+// errorCommentPrefix marks the lines snip adds to the front matter to explain
+// why the editor opened again.
+const errorCommentPrefix = "# snip: "
+
+var errEditCanceled = errors.New("edit canceled")
+
+// frontMatterFields are the note fields in the editor front matter, every
+// other key is a property.
+var frontMatterFields = property.Reserved
+
+// noteFrontMatter is the block on top of the editor buffer. A field left out
+// keeps the note's current value, a property left out is removed.
+type noteFrontMatter struct {
+	Title    *string   `yaml:"title"`
+	Slug     *string   `yaml:"slug"`
+	Tags     *[]string `yaml:"tags,flow"`
+	Language *string   `yaml:"language,omitempty"`
+}
+
+// noteBuffer is what the editor opens for a note: its title, slug, tags,
+// language when it has one and properties as front matter, then its content.
+func noteBuffer(n *note.NoteWithTags, title string) (string, error) {
+	tags := n.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	fm := noteFrontMatter{Title: &title, Slug: &n.Slug, Tags: &tags}
+	if n.Language != "" {
+		fm.Language = &n.Language
+	}
+	front, err := yaml.Marshal(fm)
+	if err != nil {
+		return "", fmt.Errorf("failed to write front matter: %w", err)
+	}
+
+	if len(n.Properties) > 0 {
+		properties, err := yaml.Marshal(n.Properties)
+		if err != nil {
+			return "", fmt.Errorf("failed to write front matter: %w", err)
+		}
+		front = append(front, properties...)
+	}
+
+	return "---\n" + string(front) + "---\n" + n.Content, nil
+}
+
+// parseNoteBuffer reads an edited buffer back into the changes to apply to n.
+// Without front matter the whole buffer is the content.
+func (h *handler) parseNoteBuffer(n *note.NoteWithTags, buffer string) (*note.Edit, error) {
+	edit := &note.Edit{Title: n.Title, Slug: n.Slug, Content: buffer, Language: n.Language, Tags: n.Tags, Properties: n.Properties}
+
+	front, body, ok := note.SplitFrontMatter(buffer)
+	if !ok {
+		if strings.HasPrefix(buffer, "---\n") || strings.HasPrefix(buffer, "---\r\n") {
+			return nil, errors.New("the front matter has no closing '---' line")
+		}
+		return edit, nil
+	}
+	edit.Content = body
+
+	var fields map[string]any
+	if err := yaml.Unmarshal([]byte(front), &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range frontMatterFields {
+		delete(fields, field)
+	}
+	properties, err := property.FromMap(fields)
+	if err != nil {
+		return nil, err
+	}
+	edit.Properties = properties
+
+	var fm noteFrontMatter
+	if err := yaml.Unmarshal([]byte(front), &fm); err != nil {
+		return nil, err
+	}
+
+	if fm.Title != nil {
+		edit.Title = strings.TrimSpace(*fm.Title)
+		if err := h.validator.ValidateNote(edit.Title); err != nil {
+			return nil, err
+		}
+	}
+
+	if fm.Slug != nil {
+		edit.Slug = strings.TrimSpace(*fm.Slug)
+		if err := h.validator.ValidateSlug(edit.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	if fm.Language != nil {
+		lang, err := language.Normalize(*fm.Language)
+		if err != nil {
+			return nil, err
+		}
+		edit.Language = lang
+	}
+
+	if fm.Tags != nil {
+		edit.Tags = nil
+		for _, name := range *fm.Tags {
+			if err := h.validator.ValidateTag(name); err != nil {
+				return nil, err
+			}
+			if name = tag.NormalizePath(name); !slices.Contains(edit.Tags, name) {
+				edit.Tags = append(edit.Tags, name)
+			}
+		}
+	}
+
+	return edit, nil
+}
+
+// editNote opens a note in the editor and applies the edit in one go. When the
+// front matter is invalid the editor opens again on the same file with the
+// error on top, so nothing typed is lost. Saving it without a change gives up
+// and leaves the file in place, emptying it cancels the edit.
+func (h *handler) editNote(n *note.NoteWithTags, title string) (*note.Edit, error) {
+	buffer, err := noteBuffer(n, title)
+	if err != nil {
+		return nil, err
+	}
+
+	tempFile, err := h.editorHandler.HandleEditor(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	var shown string
+	for {
+		edited, err := h.editorHandler.ReadTempFile(tempFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if shown != "" && strings.TrimSpace(edited) == "" {
+			h.editorHandler.RemoveTempFile(tempFile)
+			return nil, errEditCanceled
+		}
+
+		edit, err := h.parseNoteBuffer(n, edited)
+		if err == nil {
+			err = h.noteRepo.ApplyEdit(n.ID, edit)
+			if err == nil {
+				h.editorHandler.RemoveTempFile(tempFile)
+				return edit, nil
+			}
+			if !errors.Is(err, repository.ErrSlugTaken) {
+				return nil, fmt.Errorf("failed to update note: %w, your edit is kept in %s", err, tempFile.Name())
+			}
+		}
+
+		next := withErrorComment(edited, err)
+		if next == shown {
+			return nil, fmt.Errorf("invalid front matter: %w, your edit is kept in %s", err, tempFile.Name())
+		}
+
+		shown = next
+		if err := os.WriteFile(tempFile.Name(), []byte(shown), 0600); err != nil {
+			return nil, fmt.Errorf("failed to write temp file: %w", err)
+		}
+		if err := h.editorHandler.EditFile(tempFile.Name()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// withErrorComment puts err as comments at the top of the front matter of
+// buffer, replacing the ones added before.
+func withErrorComment(buffer string, err error) string {
+	_, rest, _ := strings.Cut(buffer, "\n")
+
+	var kept []string
+	for _, line := range strings.SplitAfter(rest, "\n") {
+		if !strings.HasPrefix(line, errorCommentPrefix) {
+			kept = append(kept, line)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, line := range strings.Split(err.Error(), "\n") {
+		b.WriteString(errorCommentPrefix + line + "\n")
+	}
+	b.WriteString(errorCommentPrefix + "fix the front matter and save, or empty the file to cancel\n")
+	b.WriteString(strings.Join(kept, ""))
+
+	return b.String()
+}
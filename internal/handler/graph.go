@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/matheuzgomes/Snip/internal/graph"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/search"
+)
+
+// ExportGraph writes the notes, their tags and the links between them as DOT or
+// JSON to output, or to stdout when output is empty. Notes can be limited to a
+// tag (and its subtags) and to those created since a date or duration.
+func (h *handler) ExportGraph(format string, output string, tagName string, since string) error {
+	if format != "dot" && format != "json" {
+		return fmt.Errorf("invalid format '%s' (use dot or json)", format)
+	}
+
+	tagID := 0
+	if tagName != "" {
+		tagObj, err := h.tagRepo.GetByName(tagName)
+		if err != nil {
+			return fmt.Errorf("no note found for this tag: %s", tagName)
+		}
+		tagID = tagObj.ID
+	}
+
+	notes, err := h.noteRepo.GetAll(true, tagID, true)
+	if err != nil {
+		return fmt.Errorf("failed to fetch notes: %w", err)
+	}
+
+	if since != "" {
+		sinceTime, err := search.ParseSince(since)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+
+		var recent []*note.NoteWithTags
+		for _, n := range notes {
+			if !n.CreatedAt.Before(sinceTime) {
+				recent = append(recent, n)
+			}
+		}
+		notes = recent
+	}
+
+	tags, err := h.tagRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	links, err := h.noteRepo.GetAllLinks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch links: %w", err)
+	}
+
+	g := graph.Build(notes, tags, links)
+
+	var w io.Writer = h.out
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create graph file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if format == "dot" {
+		err = g.WriteDOT(w)
+	} else {
+		err = g.WriteJSON(w)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+
+	if output == "" {
+		return nil
+	}
+
+	message := fmt.Sprintf("Graph with %d node(s) and %d edge(s) written!", len(g.Nodes), len(g.Edges))
+	return h.report(Result{Status: "written", Location: output, Message: message}, "✓ %s\n  Location: %s\n", message, output)
+}
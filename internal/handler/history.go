@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/diff"
+	"github.com/matheuzgomes/Snip/internal/link"
+	"github.com/matheuzgomes/Snip/internal/revision"
+)
+
+const diffContext = 3
+
+// noteDiff is the structured form of a diff between two revisions.
+type noteDiff struct {
+	NoteID int    `json:"note_id"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Diff   string `json:"diff"`
+}
+
+// recordRevision snapshots a note after a change and applies the retention
+// policy to its history.
+func (h *handler) recordRevision(noteID int) error {
+	if err := h.noteRepo.SaveRevision(noteID); err != nil {
+		return fmt.Errorf("failed to save revision: %w", err)
+	}
+
+	if err := h.noteRepo.PruneRevisions(noteID, h.historyLimit); err != nil {
+		return fmt.Errorf("failed to prune revisions: %w", err)
+	}
+
+	return nil
+}
+
+func (h *handler) ShowHistory(idStr string) error {
+	id, err := h.resolveNote(idStr)
+	if err != nil {
+		return err
+	}
+
+	note, err := h.noteRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch note: %w", err)
+	}
+
+	revisions, err := h.noteRepo.GetRevisions(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch revisions: %w", err)
+	}
+
+	if h.out.Structured() {
+		return h.out.Render(listOf(revisions))
+	}
+
+	if len(revisions) == 0 {
+		fmt.Fprintln(h.out, "No revisions found.")
+		return nil
+	}
+
+	fmt.Fprintf(h.out, "Found %d revision(s) of #%d %s:\n\n", len(revisions), note.ID, note.Title)
+
+	for i, rev := range revisions {
+		current := ""
+		if i == 0 {
+			current = " (current)"
+		}
+
+		fmt.Fprintf(h.out, "● r%d %s%s\n", rev.Number, rev.CreatedAt.Format(h.dateFormat), current)
+		fmt.Fprintf(h.out, "  └── %s [%s]\n", rev.Title, strings.Join(rev.Tags, ", "))
+		fmt.Fprintf(h.out, "      %d line(s), %d character(s)\n", strings.Count(rev.Content, "\n")+1, len(rev.Content))
+		fmt.Fprintln(h.out)
+	}
+
+	return nil
+}
+
+func (h *handler) DiffNote(idStr string, fromStr string, toStr string) error {
+	id, err := h.resolveNote(idStr)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.noteRepo.GetRevisions(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch revisions: %w", err)
+	}
+	if len(revisions) == 0 {
+		return fmt.Errorf("note #%d has no revisions", id)
+	}
+
+	to := revisions[0].Number
+	if toStr != "" {
+		if to, err = parseRevisionNumber(toStr); err != nil {
+			return err
+		}
+	}
+
+	from := to - 1
+	if fromStr != "" {
+		if from, err = parseRevisionNumber(fromStr); err != nil {
+			return err
+		}
+	}
+
+	fromRev, err := h.noteRepo.GetRevision(id, from)
+	if err != nil {
+		return fmt.Errorf("failed to fetch revision r%d: %w", from, err)
+	}
+
+	toRev, err := h.noteRepo.GetRevision(id, to)
+	if err != nil {
+		return fmt.Errorf("failed to fetch revision r%d: %w", to, err)
+	}
+
+	unified := diff.Unified(
+		fmt.Sprintf("#%d r%d (%s)", id, fromRev.Number, fromRev.CreatedAt.Format(h.dateFormat)),
+		fmt.Sprintf("#%d r%d (%s)", id, toRev.Number, toRev.CreatedAt.Format(h.dateFormat)),
+		revisionDocument(fromRev),
+		revisionDocument(toRev),
+		diffContext,
+	)
+
+	if h.out.Structured() {
+		return h.out.Render(noteDiff{NoteID: id, From: from, To: to, Diff: unified})
+	}
+
+	if unified == "" {
+		fmt.Fprintf(h.out, "No differences between r%d and r%d.\n", from, to)
+		return nil
+	}
+
+	fmt.Fprint(h.out, colorizeDiff(unified, h.colors()))
+	return nil
+}
+
+func (h *handler) RevertNote(idStr string, revStr string) error {
+	id, err := h.resolveNote(idStr)
+	if err != nil {
+		return err
+	}
+
+	number, err := parseRevisionNumber(revStr)
+	if err != nil {
+		return err
+	}
+
+	if err := h.noteRepo.CheckByID(id); err != nil {
+		return fmt.Errorf("failed to fetch note: %w", err)
+	}
+
+	rev, err := h.noteRepo.GetRevision(id, number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch revision r%d: %w", number, err)
+	}
+
+	if err := h.noteRepo.RevertNote(id, rev, link.Parse(rev.Content), h.historyLimit); err != nil {
+		return fmt.Errorf("failed to revert note: %w", err)
+	}
+
+	message := fmt.Sprintf("Note #%d reverted to r%d!", id, number)
+	return h.report(Result{Status: "reverted", ID: id, Message: message}, "✓ %s\n", message)
+}
+
+// parseRevisionNumber accepts both "3" and "r3".
+func parseRevisionNumber(s string) (int, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(s), "r"))
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid revision: %s", s)
+	}
+	return number, nil
+}
+
+func revisionDocument(rev *revision.Revision) string {
+	return fmt.Sprintf("Title: %s\nTags: %s\n\n%s", rev.Title, strings.Join(rev.Tags, ", "), rev.Content)
+}
+
+func colorizeDiff(unified string, color bool) string {
+	if !color {
+		return unified
+	}
+
+	lines := strings.SplitAfter(unified, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = "\033[1m" + strings.TrimSuffix(line, "\n") + "\033[0m\n"
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = "\033[36m" + strings.TrimSuffix(line, "\n") + "\033[0m\n"
+		case strings.HasPrefix(line, "+"):
+			lines[i] = "\033[32m" + strings.TrimSuffix(line, "\n") + "\033[0m\n"
+		case strings.HasPrefix(line, "-"):
+			lines[i] = "\033[31m" + strings.TrimSuffix(line, "\n") + "\033[0m\n"
+		}
+	}
+
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const readChunkSize = 64 * 1024
+
+var (
+	ErrBinaryInput = errors.New("input looks binary, only text can be stored in a note")
+	ErrInvalidUTF8 = errors.New("input is not valid UTF-8 text")
+)
+
+// ReadContent reads note content from r, such as a pipe or a file. Input is
+// read and checked a chunk at a time, so binary data is rejected as soon as it
+// shows up instead of after it has all been read.
+func ReadContent(r io.Reader) (string, error) {
+	var b strings.Builder
+	buf := make([]byte, readChunkSize)
+	// bytes of a rune split across two reads, kept at the start of buf
+	pending := 0
+
+	for {
+		n, err := r.Read(buf[pending:])
+		data := buf[:pending+n]
+
+		complete := len(data)
+		if err == nil {
+			complete = completeRunes(data)
+		}
+
+		if bytes.IndexByte(data[:complete], 0) != -1 {
+			return "", ErrBinaryInput
+		}
+		if !utf8.Valid(data[:complete]) {
+			return "", ErrInvalidUTF8
+		}
+		b.Write(data[:complete])
+		pending = copy(buf, data[complete:])
+
+		if err == io.EOF {
+			return b.String(), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+	}
+}
+
+// completeRunes returns how many bytes of p end on a rune boundary.
+func completeRunes(p []byte) int {
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(p[i]) {
+			if utf8.FullRune(p[i:]) {
+				return len(p)
+			}
+			return i
+		}
+	}
+	return len(p)
+}
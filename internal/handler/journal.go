@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/render"
+	"github.com/matheuzgomes/Snip/internal/search"
+)
+
+const journalTag = "journal"
+
+// journalDateLayout is how journal notes are titled and looked up.
+const journalDateLayout = "2006-01-02"
+
+// datePattern tells a mistyped date like 2025-02-30 from a duration.
+var datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// journalData is what the journal template sees.
+type journalData struct {
+	Date  time.Time
+	Title string
+}
+
+// OpenJournal opens the journal note of a day in the editor, creating it from
+// the journal template on first use. The day is "today", "yesterday", a date
+// or how long ago it was ('3d').
+func (h *handler) OpenJournal(date string) error {
+	day, err := journalDay(date)
+	if err != nil {
+		return err
+	}
+
+	id, err := h.journalNote(day)
+	if err != nil {
+		return err
+	}
+
+	return h.UpdateNote(strconv.Itoa(id), "")
+}
+
+// ShowJournal prints the latest journal notes, oldest first, so they read like
+// a diary.
+func (h *handler) ShowJournal(days int) error {
+	notes, err := h.noteRepo.GetJournals(days)
+	if err != nil {
+		return fmt.Errorf("failed to fetch journal notes: %w", err)
+	}
+	slices.Reverse(notes)
+
+	if h.out.Structured() {
+		return h.out.Render(listOf(notes))
+	}
+
+	if len(notes) == 0 {
+		fmt.Fprintln(h.out, "No journal notes yet, start one with 'snip today'.")
+		return nil
+	}
+
+	for i, n := range notes {
+		if i > 0 {
+			fmt.Fprintln(h.out)
+		}
+		fmt.Fprintf(h.out, "● #%d %s\n", n.ID, n.Title)
+		fmt.Fprintln(h.out, h.renderMarkdownContent(strings.TrimSpace(n.Content)))
+	}
+
+	return nil
+}
+
+// journalNote returns the ID of the journal note of day, creating it when the
+// day has none.
+func (h *handler) journalNote(day time.Time) (int, error) {
+	date := day.Format(journalDateLayout)
+
+	existing, err := h.noteRepo.GetJournal(date)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch journal note: %w", err)
+	}
+	if existing != nil {
+		if existing.DeletedAt != nil {
+			return 0, fmt.Errorf("the journal note of %s is in the trash, restore it with 'snip trash restore %d'", date, existing.ID)
+		}
+		return existing.ID, nil
+	}
+
+	if err := h.checkJournalSlug(date); err != nil {
+		return 0, err
+	}
+
+	content, err := h.journalContent(day, date)
+	if err != nil {
+		return 0, err
+	}
+
+	newNote := note.NewNote(date, content)
+	newNote.JournalDate = date
+	if err := h.noteRepo.Create(newNote); err != nil {
+		// the unique journal date lets only one of two concurrent runs create it
+		if existing, _ := h.noteRepo.GetJournal(date); existing != nil && existing.DeletedAt == nil {
+			return existing.ID, nil
+		}
+		return 0, fmt.Errorf("failed to create journal note: %w", err)
+	}
+
+	tag := journalTag
+	if err := h.AssociateTagsWithNote(&tag, newNote.ID); err != nil {
+		return 0, fmt.Errorf("failed to associate tags with note: %w", err)
+	}
+
+	if err := h.syncLinks(newNote.ID, newNote.Content); err != nil {
+		return 0, err
+	}
+
+	if err := h.recordRevision(newNote.ID); err != nil {
+		return 0, err
+	}
+
+	h.printf("Journal note for %s created.\n", date)
+	return newNote.ID, nil
+}
+
+func (h *handler) journalContent(day time.Time, title string) (string, error) {
+	tmpl, err := render.ParseTemplate(h.journalTmpl, render.TemplateOptions{DateFormat: h.dateFormat, MarkdownWidth: h.markdownWidth})
+	if err != nil {
+		return "", fmt.Errorf("journal_template: %w", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, journalData{Date: day, Title: title}); err != nil {
+		return "", fmt.Errorf("failed to render journal_template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// checkJournalSlug makes sure the date is free as a slug, so the new journal
+// note gets it rather than a numbered one that lookups by date would miss.
+func (h *handler) checkJournalSlug(date string) error {
+	notes, err := h.noteRepo.GetAll(true, 0, false)
+	if err != nil {
+		return fmt.Errorf("failed to fetch notes: %w", err)
+	}
+	trashed, err := h.noteRepo.GetDeleted()
+	if err != nil {
+		return fmt.Errorf("failed to fetch trash: %w", err)
+	}
+
+	for _, n := range append(notes, trashed...) {
+		if strings.EqualFold(n.Slug, date) {
+			return fmt.Errorf("note #%d already has the slug '%s' of this journal note, change it with 'snip update %d'", n.ID, date, n.ID)
+		}
+	}
+	return nil
+}
+
+func journalDay(value string) (time.Time, error) {
+	now := time.Now()
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "today":
+		return now, nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	}
+
+	if day, err := time.ParseInLocation(journalDateLayout, value, time.Local); err == nil {
+		return day, nil
+	} else if datePattern.MatchString(value) {
+		return time.Time{}, fmt.Errorf("invalid date '%s'", value)
+	}
+
+	day, err := search.ParseSince(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid journal date: %w", err)
+	}
+	return day, nil
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/link"
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+// syncLinks stores the [[references]] written in a note's content.
+func (h *handler) syncLinks(noteID int, content string) error {
+	if err := h.noteRepo.SaveLinks(noteID, link.Parse(content)); err != nil {
+		return fmt.Errorf("failed to save links: %w", err)
+	}
+	return nil
+}
+
+func (h *handler) ShowLinks(broken bool) error {
+	links, err := h.noteRepo.GetAllLinks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch links: %w", err)
+	}
+
+	if broken {
+		var dangling []*link.Link
+		for _, l := range links {
+			if l.IsBroken() {
+				dangling = append(dangling, l)
+			}
+		}
+		links = dangling
+	}
+
+	if h.out.Structured() {
+		return h.out.Render(listOf(links))
+	}
+
+	if len(links) == 0 {
+		if broken {
+			fmt.Fprintln(h.out, "No broken links found.")
+		} else {
+			fmt.Fprintln(h.out, "No links found.")
+		}
+		return nil
+	}
+
+	fmt.Fprintf(h.out, "Found %d link(s):\n\n", len(links))
+
+	for _, l := range links {
+		fmt.Fprintf(h.out, "● #%d %s → %s\n", l.SourceID, l.SourceTitle, formatTarget(l))
+	}
+
+	return nil
+}
+
+// printNoteLinks lists the outgoing links and backlinks of a note under it.
+func (h *handler) printNoteLinks(noteID int) error {
+	links, err := h.noteRepo.GetLinks(noteID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch links: %w", err)
+	}
+
+	backlinks, err := h.noteRepo.GetBacklinks(noteID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch backlinks: %w", err)
+	}
+
+	// a note may reference the same target both by title and by id
+	var targets, sources []string
+	for _, l := range links {
+		targets = appendUnique(targets, formatTarget(l))
+	}
+	for _, l := range backlinks {
+		sources = appendUnique(sources, fmt.Sprintf("#%d %s", l.SourceID, l.SourceTitle))
+	}
+
+	if len(targets) > 0 {
+		fmt.Fprintf(h.out, "  └─ Links: %s\n", strings.Join(targets, ", "))
+	}
+	if len(sources) > 0 {
+		fmt.Fprintf(h.out, "  └─ Backlinks: %s\n", strings.Join(sources, ", "))
+	}
+
+	return nil
+}
+
+// noteDetails is the structured form of a single note with its links.
+type noteDetails struct {
+	*note.NoteWithTags
+	Links     []*link.Link `json:"links"`
+	Backlinks []*link.Link `json:"backlinks"`
+}
+
+func (h *handler) renderNoteDetails(n *note.NoteWithTags) error {
+	links, err := h.noteRepo.GetLinks(n.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch links: %w", err)
+	}
+
+	backlinks, err := h.noteRepo.GetBacklinks(n.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch backlinks: %w", err)
+	}
+
+	return h.out.Render(noteDetails{NoteWithTags: n, Links: listOf(links), Backlinks: listOf(backlinks)})
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func countTitleLinks(links []*link.Link) int {
+	count := 0
+	for _, l := range links {
+		if l.IsByTitle() {
+			count++
+		}
+	}
+	return count
+}
+
+func formatTarget(l *link.Link) string {
+	if l.IsBroken() {
+		return fmt.Sprintf("[[%s]] (broken)", l.Ref)
+	}
+	return fmt.Sprintf("#%d %s", l.TargetID, l.TargetTitle)
+}
+
+// rewriteInboundLinks updates [[oldTitle]] references in the notes of
+// backlinks to [[newTitle]] and returns how many notes changed.
+func (h *handler) rewriteInboundLinks(backlinks []*link.Link, oldTitle, newTitle string) (int, error) {
+	rewritten := 0
+
+	for _, l := range backlinks {
+		if !l.IsByTitle() {
+			continue
+		}
+
+		source, err := h.noteRepo.GetByID(l.SourceID)
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to fetch note #%d: %w", l.SourceID, err)
+		}
+
+		content, count := link.RewriteTitle(source.Content, oldTitle, newTitle)
+		if count == 0 {
+			continue
+		}
+
+		if err := h.noteRepo.Update(source.ID, content, ""); err != nil {
+			return rewritten, fmt.Errorf("failed to update note #%d: %w", source.ID, err)
+		}
+		if err := h.syncLinks(source.ID, content); err != nil {
+			return rewritten, err
+		}
+		if err := h.recordRevision(source.ID); err != nil {
+			return rewritten, err
+		}
+		rewritten++
+	}
+
+	return rewritten, nil
+}
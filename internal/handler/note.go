@@ -2,39 +2,67 @@ package handler
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/language"
 	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/notebook"
+	"github.com/matheuzgomes/Snip/internal/notetemplate"
+	"github.com/matheuzgomes/Snip/internal/property"
 	"github.com/matheuzgomes/Snip/internal/repository"
+	"github.com/matheuzgomes/Snip/internal/search"
 	"github.com/matheuzgomes/Snip/internal/validation"
 
 	"github.com/mitchellh/go-wordwrap"
+	"gopkg.in/yaml.v3"
 
 	markdown "github.com/MichaelMure/go-term-markdown"
 )
 
-const lineLimit = 62
-const rowsLimit = 4
-const markdownWidth = 100
 const markdownPad = 2
 
 type Handler interface {
-	CreateNote(title string, message *string, tag *string) error
-	ListNotes(isAsc, verbose bool, tag *string) error
+	CreateNote(title string, message *string, tag *string, lang string) error
+	CreateNoteFromTemplate(title string, tmpl *notetemplate.Template, vars map[string]string, tag *string) error
+	ListNotes(isAsc, verbose bool, tag *string, withSubtags bool, where []string, lang string) error
 	GetNote(idStr string, verbose bool, format bool) error
 	FindNotes(term string) error
 	UpdateNote(idStr string, title string) error
+	AppendNote(idStr string, content string, timestamp bool) error
+	PrependNote(idStr string, content string, timestamp bool) error
+	OpenJournal(date string) error
+	ShowJournal(days int) error
 	DeleteNote(idStr string) error
-	PatchNote(idStr string, title *string, tag *string) error
+	PatchNote(idStr string, title *string, tag *string, lang *string, rewriteLinks bool) error
+	UseNote(idStr string, vars map[string]string, outFile string, run bool, force bool) error
+	SetProperties(idStr string, pairs []string) error
+	UnsetProperties(idStr string, keys []string) error
 	GetRecentNotes(limit int) error
 	ExportNotes(since string, format string) error
 	BackupDatabase() error
-	ImportNotes(importDir string) error
+	ImportNotes(importDir string, snippets bool) error
+	ShowHistory(idStr string) error
+	DiffNote(idStr string, from string, to string) error
+	RevertNote(idStr string, rev string) error
+	ListTrash() error
+	RestoreNote(idStr string) error
+	EmptyTrash(olderThan string, force bool) error
+	ShowLinks(broken bool) error
+	ExportGraph(format string, output string, tag string, since string) error
+	ListTags() error
+	ShowTagTree() error
+	RenameTag(oldName string, newName string) error
+	MergeTags(sources []string, into string) error
+	DeleteTag(name string) error
+	PruneTags() error
 }
 
 type handler struct {
@@ -43,29 +71,103 @@ type handler struct {
 	validator     *validation.Validator
 	editorHandler *EditorHandler
 	dateFormat    string
+	lineLimit     uint
+	rowsLimit     int
+	markdownWidth int
+	exportDir     string
+	historyLimit  int
+	journalTmpl   string
+	notebook      *notebook.Notebook
+	output
 }
 
-func NewHandler(noteRepo repository.NoteRepository, tagRepo repository.TagRepository) Handler {
-	return &handler{
-		noteRepo:      noteRepo,
-		tagRepo:       tagRepo,
-		validator:     validation.NewValidator(),
-		dateFormat:    "2006-01-02 15:04:05",
-		editorHandler: NewEditorHandler(),
+// Option customizes a handler created with NewHandler.
+type Option func(*handler)
+
+// WithConfig applies the user settings. Without it the compiled-in defaults
+// are used.
+func WithConfig(cfg *config.Config) Option {
+	return func(h *handler) {
+		h.dateFormat = cfg.DateFormat
+		h.lineLimit = uint(cfg.LineLimit)
+		h.rowsLimit = cfg.RowsLimit
+		h.markdownWidth = cfg.MarkdownWidth
+		h.exportDir = cfg.ExportDir
+		h.historyLimit = cfg.HistoryLimit
+		h.journalTmpl = cfg.JournalTemplate
+		h.editorHandler = NewEditorHandlerFromConfig(cfg)
 	}
 }
 
-func (h *handler) CreateNote(title string, message *string, tag *string) error {
+// WithNotebook makes backups and exports go to the notebook's directories.
+// Without it the default notebook is used.
+func WithNotebook(nb *notebook.Notebook) Option {
+	return func(h *handler) {
+		h.notebook = nb
+	}
+}
+
+func NewHandler(noteRepo repository.NoteRepository, tagRepo repository.TagRepository, opts ...Option) Handler {
+	h := &handler{
+		noteRepo:  noteRepo,
+		tagRepo:   tagRepo,
+		validator: validation.NewValidator(),
+		output:    newOutput(nil),
+	}
+
+	WithConfig(config.Default())(h)
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func (h *handler) activeNotebook() (*notebook.Notebook, error) {
+	if h.notebook != nil {
+		return h.notebook, nil
+	}
+
+	store, err := notebook.DefaultStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.Get(notebook.DefaultName)
+}
+
+// CreateNote saves a new note. lang is the code language of a snippet, "auto"
+// to detect it from the content, or "" for a note that is not code.
+func (h *handler) CreateNote(title string, message *string, tag *string, lang string) error {
 	if err := h.validator.ValidateNote(title); err != nil {
 		return err
 	}
 
+	if lang != language.Auto {
+		normalized, err := language.Normalize(lang)
+		if err != nil {
+			return err
+		}
+		lang = normalized
+	}
+
 	contentStr, err := HandleMessage(message, h)
 	if err != nil {
 		return err
 	}
 
+	if lang == language.Auto {
+		lang = language.Detect(contentStr)
+	}
+
+	return h.saveNewNote(title, contentStr, tag, lang)
+}
+
+// saveNewNote stores a new note with its tags, links and first revision.
+func (h *handler) saveNewNote(title string, contentStr string, tag *string, lang string) error {
 	newNote := note.NewNote(title, contentStr)
+	newNote.Language = lang
 	if err := h.noteRepo.Create(newNote); err != nil {
 		return fmt.Errorf("failed to create note: %w", err)
 	}
@@ -76,13 +178,29 @@ func (h *handler) CreateNote(title string, message *string, tag *string) error {
 		}
 	}
 
-	fmt.Printf("Note created successfully!\n")
-	fmt.Printf("● #%d  %s\n", newNote.ID, newNote.Title)
+	if err := h.syncLinks(newNote.ID, newNote.Content); err != nil {
+		return err
+	}
 
-	return nil
+	if err := h.recordRevision(newNote.ID); err != nil {
+		return err
+	}
+
+	result := Result{Status: "created", ID: newNote.ID, Message: "Note created successfully!"}
+	return h.report(result, "Note created successfully!\n● #%d  %s\n", newNote.ID, newNote.Title)
 }
 
-func (h *handler) ListNotes(isAsc, verbose bool, tag *string) error {
+func (h *handler) ListNotes(isAsc, verbose bool, tag *string, withSubtags bool, where []string, lang string) error {
+	conditions, err := parseConditions(where)
+	if err != nil {
+		return err
+	}
+
+	lang, err = language.Normalize(lang)
+	if err != nil {
+		return err
+	}
+
 	tagID := 0
 
 	if tag != nil && *tag != "" {
@@ -93,29 +211,39 @@ func (h *handler) ListNotes(isAsc, verbose bool, tag *string) error {
 		tagID = tagObj.ID
 	}
 
-	notes, err := h.noteRepo.GetAll(isAsc, tagID)
+	notes, err := h.noteRepo.GetAll(isAsc, tagID, withSubtags)
 	if err != nil {
 		return fmt.Errorf("failed to fetch notes: %w", err)
 	}
+	notes = filterNotes(notes, conditions)
+	if lang != "" {
+		notes = slices.DeleteFunc(notes, func(n *note.NoteWithTags) bool {
+			return n.Language != lang
+		})
+	}
+
+	if h.out.Structured() {
+		return h.out.Render(listOf(notes))
+	}
 
 	if len(notes) == 0 {
-		fmt.Println("No notes found.")
+		fmt.Fprintln(h.out, "No notes found.")
 		return nil
 	}
 
-	fmt.Printf("Found %d note(s):\n\n", len(notes))
+	fmt.Fprintf(h.out, "Found %d note(s):\n\n", len(notes))
 
-	writer := bufio.NewWriter(os.Stdout)
+	writer := bufio.NewWriter(h.out)
 
 	for _, note := range notes {
 		tags := strings.Join(note.Tags, ", ")
 		fmt.Fprintf(writer, "● #%d %s [%s]\n", note.ID, note.Title, tags)
 
-		lines := strings.Split(strings.TrimRight(wordwrap.WrapString(note.Content, lineLimit), "\n"), "\n")
+		lines := strings.Split(strings.TrimRight(wordwrap.WrapString(note.Content, h.lineLimit), "\n"), "\n")
 
-		if len(lines) > rowsLimit {
-			lines = lines[:rowsLimit]
-			lines[rowsLimit-1] = "..."
+		if len(lines) > h.rowsLimit {
+			lines = lines[:h.rowsLimit]
+			lines[h.rowsLimit-1] = "..."
 		}
 
 		fmt.Fprintf(writer, "   └── ")
@@ -131,6 +259,12 @@ func (h *handler) ListNotes(isAsc, verbose bool, tag *string) error {
 		if verbose {
 			fmt.Fprintf(writer, "  └─ Created: %s\n", note.CreatedAt.Format(h.dateFormat))
 			fmt.Fprintf(writer, "  └─ Updated: %s\n", note.UpdatedAt.Format(h.dateFormat))
+			if note.Language != "" {
+				fmt.Fprintf(writer, "  └─ Language: %s\n", note.Language)
+			}
+			if len(note.Properties) > 0 {
+				fmt.Fprintf(writer, "  └─ Properties: %s\n", formatProperties(note.Properties))
+			}
 		}
 
 		fmt.Fprintln(writer)
@@ -141,102 +275,149 @@ func (h *handler) ListNotes(isAsc, verbose bool, tag *string) error {
 }
 
 func (h *handler) GetNote(idStr string, verbose bool, render bool) error {
-	id, err := strconv.Atoi(idStr)
+	id, err := h.resolveNote(idStr)
 	if err != nil {
-		return fmt.Errorf("invalid note ID: %s", idStr)
+		return err
 	}
 
 	note, err := h.noteRepo.GetByID(id)
 	if err != nil {
 		return fmt.Errorf("failed to fetch note -> %w", err)
 	}
+
+	if h.out.Structured() {
+		return h.renderNoteDetails(note)
+	}
+
 	tags := strings.Join(note.Tags, ", ")
 
-	fmt.Printf("● #%d %s [%s]\n", note.ID, note.Title, tags)
+	fmt.Fprintf(h.out, "● #%d %s [%s]\n", note.ID, note.Title, tags)
 
 	if note.Content != "" {
 		if render {
-			fmt.Println("\n" + renderMarkdownContent(note.Content))
+			fmt.Fprintln(h.out, "\n"+h.renderMarkdownContent(note.Content))
+		} else if note.Language != "" {
+			h.printCode(note.Content, note.Language)
 		} else {
-			lines := strings.Split(strings.TrimRight(wordwrap.WrapString(note.Content, lineLimit), "\n"), "\n")
-			fmt.Printf("  └── ")
+			lines := strings.Split(strings.TrimRight(wordwrap.WrapString(note.Content, h.lineLimit), "\n"), "\n")
+			fmt.Fprintf(h.out, "  └── ")
 
 			for i, line := range lines {
 				if i != 0 {
-					fmt.Printf("      %s\n", line)
+					fmt.Fprintf(h.out, "      %s\n", line)
 				} else if i == 0 {
-					fmt.Printf("%s\n", line)
+					fmt.Fprintf(h.out, "%s\n", line)
 				}
 			}
 		}
 	}
 
 	if verbose {
-		fmt.Printf("  └─ Created: %s\n", note.CreatedAt.Format(h.dateFormat))
-		fmt.Printf("  └─ Updated: %s\n", note.UpdatedAt.Format(h.dateFormat))
+		fmt.Fprintf(h.out, "  └─ Created: %s\n", note.CreatedAt.Format(h.dateFormat))
+		fmt.Fprintf(h.out, "  └─ Updated: %s\n", note.UpdatedAt.Format(h.dateFormat))
+		if note.Slug != "" {
+			fmt.Fprintf(h.out, "  └─ Slug: %s\n", note.Slug)
+		}
+		if note.Language != "" {
+			fmt.Fprintf(h.out, "  └─ Language: %s\n", note.Language)
+		}
+		for _, p := range note.Properties {
+			fmt.Fprintf(h.out, "  └─ %s: %s\n", p.Key, p.String())
+		}
+		fmt.Fprintf(h.out, "  └─ Hash: %s\n", shortHash(note.Content))
 	}
 
-	return nil
+	return h.printNoteLinks(note.ID)
 }
 
 func (h *handler) FindNotes(term string) error {
-	notes, err := h.noteRepo.Search(term)
+	query, err := search.Parse(term)
+	if err != nil {
+		return err
+	}
+
+	results, err := h.noteRepo.Search(query)
 	if err != nil {
 		return fmt.Errorf("failed to search notes: %w", err)
 	}
 
-	if len(notes) == 0 {
-		fmt.Println("No notes found.")
+	if h.out.Structured() {
+		return h.out.Render(plainResults(results))
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(h.out, "No notes found.")
 		return nil
 	}
 
-	fmt.Printf("Found %d note(s) matching '%s':\n\n", len(notes), term)
+	fmt.Fprintf(h.out, "Found %d note(s) matching '%s':\n\n", len(results), term)
 
-	for _, note := range notes {
-		fmt.Printf("● #%d %s\n", note.ID, note.Title)
+	color := h.colors()
 
-		if note.Content != "" {
-			lines := strings.Split(strings.TrimRight(note.Content, "\n"), "\n")
-			if len(lines) > rowsLimit {
-				lines = lines[:rowsLimit]
-				lines[rowsLimit-1] = "..."
+	for _, result := range results {
+		fmt.Fprintf(h.out, "● #%d %s\n", result.ID, highlightMatches(result.Highlight, color))
+
+		snippet := strings.Join(strings.Fields(result.Snippet), " ")
+		if snippet != "" {
+			lines := strings.Split(strings.TrimRight(wordwrap.WrapString(snippet, h.lineLimit), "\n"), "\n")
+			if len(lines) > h.rowsLimit {
+				lines = lines[:h.rowsLimit]
+				lines[h.rowsLimit-1] = "..."
 			}
 
-			fmt.Printf("  └── ")
+			fmt.Fprintf(h.out, "  └── ")
 
 			for i, line := range lines {
-				if len(line) > lineLimit {
-					line = line[:lineLimit] + "..."
-				}
+				line = highlightMatches(line, color)
 				if i != 0 {
-					fmt.Printf("      %s\n", line)
+					fmt.Fprintf(h.out, "      %s\n", line)
 				} else if i == 0 {
-					fmt.Printf("%s\n", line)
+					fmt.Fprintf(h.out, "%s\n", line)
 				}
 			}
 		}
 
-		fmt.Println()
+		fmt.Fprintln(h.out)
 	}
 
 	return nil
 }
 
-func (h *handler) PatchNote(idStr string, title *string, tag *string) error {
-	id, err := strconv.Atoi(idStr)
+func (h *handler) PatchNote(idStr string, title *string, tag *string, lang *string, rewriteLinks bool) error {
+	id, err := h.resolveNote(idStr)
 	if err != nil {
-		return fmt.Errorf("invalid note ID: %s", idStr)
+		return err
 	}
 
-	err = h.noteRepo.CheckByID(id)
+	existing, err := h.noteRepo.GetByID(id)
 	if err != nil {
 		return fmt.Errorf("failed to fetch note: %w", err)
 	}
 
-	if title != nil && *title != "" {
+	if title != nil && *title != "" && *title != existing.Title {
+		oldTitle := existing.Title
+
+		// title links resolve by name, so collect them before the rename
+		backlinks, err := h.noteRepo.GetBacklinks(id)
+		if err != nil {
+			return fmt.Errorf("failed to fetch backlinks: %w", err)
+		}
+
 		if err := h.noteRepo.Patch(id, *title); err != nil {
 			return fmt.Errorf("failed to update note: %w", err)
 		}
+
+		if rewriteLinks {
+			rewritten, err := h.rewriteInboundLinks(backlinks, oldTitle, *title)
+			if err != nil {
+				return err
+			}
+			if rewritten > 0 {
+				h.printf("✓ Links updated in %d note(s)!\n", rewritten)
+			}
+		} else if inbound := countTitleLinks(backlinks); inbound > 0 {
+			h.printf("%d note(s) link to '%s' by title, use --rewrite-links to update them.\n", inbound, oldTitle)
+		}
 	}
 
 	if tag != nil && *tag != "" {
@@ -248,13 +429,31 @@ func (h *handler) PatchNote(idStr string, title *string, tag *string) error {
 		}
 	}
 
-	return nil
+	if lang != nil && *lang != "" {
+		var normalized string
+		if *lang == language.Auto {
+			normalized = language.Detect(existing.Content)
+		} else if normalized, err = language.Normalize(*lang); err != nil {
+			return err
+		}
+		if err := h.noteRepo.SetLanguage(id, normalized); err != nil {
+			return fmt.Errorf("failed to set language: %w", err)
+		}
+	}
+
+	if err := h.recordRevision(id); err != nil {
+		return err
+	}
+
+	return h.report(Result{Status: "patched", ID: id, Message: "Note patched successfully!"}, "")
 }
 
+// UpdateNote opens a note in the editor with its title, slug, tags and
+// properties as front matter above the content, and saves all of them together.
 func (h *handler) UpdateNote(idStr string, title string) error {
-	id, err := strconv.Atoi(idStr)
+	id, err := h.resolveNote(idStr)
 	if err != nil {
-		return fmt.Errorf("invalid note ID: %d", id)
+		return err
 	}
 
 	note, err := h.noteRepo.GetByID(id)
@@ -262,30 +461,88 @@ func (h *handler) UpdateNote(idStr string, title string) error {
 		return fmt.Errorf("failed to fetch note: %w", err)
 	}
 
-	tempFile, err := h.editorHandler.HandleEditor(note.Content)
+	if strings.TrimSpace(title) == "" {
+		title = note.Title
+	}
+
+	edit, err := h.editNote(note, title)
+	if errors.Is(err, errEditCanceled) {
+		h.printf("Edit canceled, the note was not changed.\n")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	defer h.editorHandler.RemoveTempFile(tempFile)
 
-	content, err := h.editorHandler.ReadTempFile(tempFile)
+	if err := h.syncLinks(id, edit.Content); err != nil {
+		return err
+	}
+
+	if err := h.recordRevision(id); err != nil {
+		return err
+	}
+
+	return h.report(Result{Status: "updated", ID: id, Message: "Note updated successfully!"}, "Note updated successfully!\n")
+}
+
+// AppendNote adds content at the end of a note, on a line of its own.
+func (h *handler) AppendNote(idStr string, content string, timestamp bool) error {
+	return h.addToNote(idStr, content, timestamp, false)
+}
+
+// PrependNote adds content at the start of a note.
+func (h *handler) PrependNote(idStr string, content string, timestamp bool) error {
+	return h.addToNote(idStr, content, timestamp, true)
+}
+
+// addToNote writes content into a note with one UPDATE instead of a round trip
+// through the editor, so it is safe to run from scripts while logging. With
+// timestamp the content goes under a heading with the current time.
+func (h *handler) addToNote(idStr string, content string, timestamp bool, prepend bool) error {
+	id, err := h.resolveNote(idStr)
 	if err != nil {
 		return err
 	}
 
-	contentStr := string(content)
-	if err := h.noteRepo.Update(id, contentStr, title); err != nil {
-		return fmt.Errorf("failed to update note: %w", err)
+	if strings.TrimSpace(content) == "" {
+		return errors.New("nothing to add, the content is empty")
 	}
 
-	fmt.Printf("Note updated successfully!\n")
-	return nil
+	if timestamp {
+		content = fmt.Sprintf("### %s\n%s", time.Now().Format(h.dateFormat), content)
+	}
+
+	status, message := "appended", "Note appended successfully!"
+	if prepend {
+		status, message = "prepended", "Note prepended successfully!"
+		err = h.noteRepo.Prepend(id, content)
+	} else {
+		err = h.noteRepo.Append(id, content)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add to note: %w", err)
+	}
+
+	note, err := h.noteRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch note: %w", err)
+	}
+
+	if err := h.syncLinks(id, note.Content); err != nil {
+		return err
+	}
+
+	if err := h.recordRevision(id); err != nil {
+		return err
+	}
+
+	return h.report(Result{Status: status, ID: id, Message: message}, "%s\n", message)
 }
 
 func (h *handler) DeleteNote(idStr string) error {
-	id, err := strconv.Atoi(idStr)
+	id, err := h.resolveNote(idStr)
 	if err != nil {
-		return fmt.Errorf("invalid note ID: %d", id)
+		return err
 	}
 
 	if err := h.noteRepo.CheckByID(id); err != nil {
@@ -296,8 +553,8 @@ func (h *handler) DeleteNote(idStr string) error {
 		return fmt.Errorf("failed to delete note: %w", err)
 	}
 
-	fmt.Printf("Note deleted successfully!\n")
-	return nil
+	result := Result{Status: "trashed", ID: id, Message: "Note moved to the trash."}
+	return h.report(result, "Note moved to the trash.\n  Restore it with: snip trash restore %d\n", id)
 }
 
 func HandleMessage(message *string, h *handler) (string, error) {
@@ -321,7 +578,7 @@ func HandleMessage(message *string, h *handler) (string, error) {
 }
 
 func (h *handler) AssociateTagsWithNote(tag *string, noteID int) error {
-	for tag := range strings.SplitSeq(*tag, " ") {
+	for tag := range strings.FieldsSeq(*tag) {
 		tagObj, err := h.tagRepo.GetOrCreate(tag)
 		if err != nil {
 			return err
@@ -341,86 +598,93 @@ func (h *handler) GetRecentNotes(limit int) error {
 		return fmt.Errorf("failed to get recent notes: %w", err)
 	}
 
+	if h.out.Structured() {
+		return h.out.Render(listOf(notes))
+	}
+
 	if len(notes) == 0 {
-		fmt.Println("No notes found.")
+		fmt.Fprintln(h.out, "No notes found.")
 		return nil
 	}
 
-	fmt.Printf("Found %d note(s):\n\n", len(notes))
+	fmt.Fprintf(h.out, "Found %d note(s):\n\n", len(notes))
 
 	for _, note := range notes {
 		tags := strings.Join(note.Tags, ", ")
-		fmt.Printf("● #%d %s [%s]\n", note.ID, note.Title, tags)
+		fmt.Fprintf(h.out, "● #%d %s [%s]\n", note.ID, note.Title, tags)
 
-		lines := strings.Split(strings.TrimRight(wordwrap.WrapString(note.Content, lineLimit), "\n"), "\n")
-		if len(lines) > rowsLimit {
-			lines = lines[:rowsLimit]
-			lines[rowsLimit-1] = "..."
+		lines := strings.Split(strings.TrimRight(wordwrap.WrapString(note.Content, h.lineLimit), "\n"), "\n")
+		if len(lines) > h.rowsLimit {
+			lines = lines[:h.rowsLimit]
+			lines[h.rowsLimit-1] = "..."
 		}
 
-		fmt.Printf("  └── ")
+		fmt.Fprintf(h.out, "  └── ")
 
 		for i, line := range lines {
 			if i != 0 {
-				fmt.Printf("      %s\n", line)
+				fmt.Fprintf(h.out, "      %s\n", line)
 			} else if i == 0 {
-				fmt.Printf("%s\n", line)
+				fmt.Fprintf(h.out, "%s\n", line)
 			}
 		}
 
-		fmt.Println()
+		fmt.Fprintln(h.out)
 	}
 
 	return nil
 }
 
 func (h *handler) ExportNotes(since string, format string) error {
-	homeDir, err := os.UserHomeDir()
+	nb, err := h.activeNotebook()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
-	exportDir := filepath.Join(homeDir, ".snip", "export")
+	exportDir := nb.ExportDir()
+	if h.exportDir != "" {
+		exportDir = h.exportDir
+	}
 	if err := os.MkdirAll(exportDir, 0755); err != nil {
 		return fmt.Errorf("failed to create export directory: %w", err)
 	}
 
 	var sinceTime *time.Time
 	if since != "" {
-		parsed, err := parseSinceFilter(since)
+		parsed, err := search.ParseSince(since)
 		if err != nil {
 			return fmt.Errorf("invalid --since value: %w", err)
 		}
 		sinceTime = &parsed
 	}
 
-	if err := h.noteRepo.ExportNotes(exportDir, sinceTime, format); err != nil {
+	exported, err := h.noteRepo.ExportNotes(exportDir, sinceTime, format)
+	if err != nil {
 		return fmt.Errorf("failed to export notes: %w", err)
 	}
 
+	message := "Notes exported successfully!"
 	if sinceTime != nil {
-		fmt.Printf("✓ Notes exported successfully (since %s)!\n", sinceTime.Format("2006-01-02"))
-	} else {
-		fmt.Printf("✓ Notes exported successfully!\n")
+		message = fmt.Sprintf("Notes exported successfully (since %s)!", sinceTime.Format("2006-01-02"))
 	}
-	fmt.Printf("  Location: %s\n", exportDir)
-	return nil
+
+	result := Result{Status: "exported", Count: count(exported), Location: exportDir, Message: message}
+	return h.report(result, "✓ %s\n  Notes: %d\n  Location: %s\n", message, exported, exportDir)
 }
 
 func (h *handler) BackupDatabase() error {
-	homeDir, err := os.UserHomeDir()
+	nb, err := h.activeNotebook()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
-	snipDir := filepath.Join(homeDir, ".snip")
-	sourceDB := filepath.Join(snipDir, "notes.db")
+	sourceDB := nb.DBPath
 
 	if _, err := os.Stat(sourceDB); os.IsNotExist(err) {
 		return fmt.Errorf("database not found at %s", sourceDB)
 	}
 
-	backupDir := filepath.Join(snipDir, "backups")
+	backupDir := nb.BackupDir()
 	if err := os.MkdirAll(backupDir, 0755); err != nil {
 		return fmt.Errorf("failed to create backup directory: %w", err)
 	}
@@ -441,14 +705,16 @@ func (h *handler) BackupDatabase() error {
 		return fmt.Errorf("failed to finalize backup: %w", err)
 	}
 
-	fmt.Printf("✓ Database backed up successfully!\n")
-	fmt.Printf("  Location: %s\n", destDB)
-	return nil
+	result := Result{Status: "backed_up", Location: destDB, Message: "Database backed up successfully!"}
+	return h.report(result, "✓ Database backed up successfully!\n  Location: %s\n", destDB)
 }
 
-// Only import markdown files for now, ill add support for other files later dont kill me for this
-func (h *handler) ImportNotes(importDir string) error {
-	fmt.Printf("Importing notes from %s\n", importDir)
+// ImportNotes reads markdown files, taking the title, tags, language and
+// properties from their front matter when they have one, and notes exported as
+// JSON. With snippets, source files other than dotfiles come in too, as
+// snippets in the language of their extension.
+func (h *handler) ImportNotes(importDir string, snippets bool) error {
+	h.printf("Importing notes from %s\n", importDir)
 
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -461,16 +727,20 @@ func (h *handler) ImportNotes(importDir string) error {
 		return fmt.Errorf("failed to read import directory: %w", err)
 	}
 
-	fmt.Printf("Found %d files to import\n", len(files))
+	h.printf("Found %d files to import\n", len(files))
 
+	imported := 0
 	for _, file := range files {
-		fmt.Printf("Importing file: %s\n", file.Name())
+		h.printf("Importing file: %s\n", file.Name())
 		if file.IsDir() {
 			continue
 		}
 
-		if filepath.Ext(file.Name()) != ".md" {
-			continue
+		name := file.Name()
+		if ext := filepath.Ext(name); ext != ".md" && ext != ".json" {
+			if !snippets || strings.HasPrefix(name, ".") || language.FromFilename(name) == "" {
+				continue
+			}
 		}
 
 		content, err := os.ReadFile(filepath.Join(importDir, file.Name()))
@@ -478,13 +748,119 @@ func (h *handler) ImportNotes(importDir string) error {
 			return fmt.Errorf("failed to read file: %w", err)
 		}
 
-		note := note.NewNote(strings.TrimSuffix(file.Name(), ".md"), string(content))
+		parsed, err := parseImportFile(file.Name(), string(content))
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", file.Name(), err)
+		}
+
+		note := note.NewNote(parsed.Title, parsed.Content)
+		note.Language = parsed.Language
 		if err := h.noteRepo.Create(note); err != nil {
 			return fmt.Errorf("failed to create note: %w", err)
 		}
+
+		if len(parsed.Tags) > 0 {
+			tags := strings.Join(parsed.Tags, " ")
+			if err := h.AssociateTagsWithNote(&tags, note.ID); err != nil {
+				return fmt.Errorf("failed to associate tags with note: %w", err)
+			}
+		}
+
+		if len(parsed.Properties) > 0 {
+			if err := h.noteRepo.SetProperties(note.ID, parsed.Properties); err != nil {
+				return fmt.Errorf("failed to set properties: %w", err)
+			}
+		}
+
+		if err := h.syncLinks(note.ID, note.Content); err != nil {
+			return err
+		}
+
+		if err := h.recordRevision(note.ID); err != nil {
+			return err
+		}
+		imported++
 	}
 
-	return nil
+	result := Result{Status: "imported", Count: count(imported), Location: importDir, Message: "Notes imported successfully!"}
+	return h.report(result, "")
+}
+
+// parseImportFile reads the note in an imported file. A source file keeps its
+// whole name as title.
+func parseImportFile(filename, content string) (*note.NoteWithTags, error) {
+	ext := filepath.Ext(filename)
+	name := strings.TrimSuffix(filename, ext)
+
+	switch ext {
+	case ".md":
+		return parseMarkdownImport(name, content)
+	case ".json":
+		var parsed note.NoteWithTags
+		if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+			return nil, err
+		}
+		if parsed.Title == "" {
+			parsed.Title = name
+		}
+		lang, err := language.Normalize(parsed.Language)
+		if err != nil {
+			return nil, err
+		}
+		parsed.Language = lang
+		return &parsed, nil
+	default:
+		return &note.NoteWithTags{Title: filename, Content: content, Language: language.FromFilename(filename)}, nil
+	}
+}
+
+// parseMarkdownImport reads a markdown note, named after the file unless its
+// front matter has a title. Without a language key the language is detected
+// from the content.
+func parseMarkdownImport(name, content string) (*note.NoteWithTags, error) {
+	parsed := &note.NoteWithTags{Title: name, Content: content, Language: language.Detect(content)}
+
+	front, body, ok := note.SplitFrontMatter(content)
+	if !ok {
+		return parsed, nil
+	}
+	parsed.Content = body
+	parsed.Language = language.Detect(body)
+
+	var fields map[string]any
+	if err := yaml.Unmarshal([]byte(front), &fields); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	if title, ok := fields["title"].(string); ok && strings.TrimSpace(title) != "" {
+		parsed.Title = strings.TrimSpace(title)
+	}
+	switch tags := fields["tags"].(type) {
+	case []any:
+		for _, t := range tags {
+			parsed.Tags = append(parsed.Tags, fmt.Sprint(t))
+		}
+	case string:
+		parsed.Tags = strings.Fields(strings.ReplaceAll(tags, ",", " "))
+	}
+	if lang, ok := fields["language"].(string); ok {
+		normalized, err := language.Normalize(lang)
+		if err != nil {
+			return nil, err
+		}
+		parsed.Language = normalized
+	}
+
+	for _, field := range property.Reserved {
+		delete(fields, field)
+	}
+	properties, err := property.FromMap(fields)
+	if err != nil {
+		return nil, err
+	}
+	parsed.Properties = properties
+
+	return parsed, nil
 }
 
 func copyFile(src, dst string) error {
@@ -519,40 +895,60 @@ func copyFile(src, dst string) error {
 	return destFile.Sync()
 }
 
-func parseSinceFilter(since string) (time.Time, error) {
-	if t, err := time.Parse("2006-01-02", since); err == nil {
-		return t, nil
+// printCode prints a snippet as the plain content is printed, without wrapping
+// its lines and highlighted when stdout is a terminal.
+func (h *handler) printCode(content string, lang string) {
+	code := strings.TrimRight(content, "\n")
+	if h.colors() {
+		var b strings.Builder
+		if err := language.Highlight(&b, code, lang); err == nil {
+			code = b.String()
+		}
 	}
 
-	if len(since) < 2 {
-		return time.Time{}, fmt.Errorf("invalid format: %s (use '2025-01-01' or '30d')", since)
+	for i, line := range strings.Split(code, "\n") {
+		if i == 0 {
+			fmt.Fprintf(h.out, "  └── %s\n", line)
+		} else {
+			fmt.Fprintf(h.out, "      %s\n", line)
+		}
 	}
+}
 
-	unit := since[len(since)-1:]
-	valueStr := since[:len(since)-1]
+func (h *handler) renderMarkdownContent(content string) string {
+	return RenderMarkdown(content, h.markdownWidth)
+}
 
-	var value int
-	if _, err := fmt.Sscanf(valueStr, "%d", &value); err != nil {
-		return time.Time{}, fmt.Errorf("invalid number in duration: %s", since)
-	}
+// RenderMarkdown renders note content for the terminal at the given width.
+func RenderMarkdown(content string, width int) string {
+	return string(markdown.Render(content, width, markdownPad))
+}
 
-	var duration time.Duration
-	switch unit {
-	case "d":
-		duration = time.Duration(value) * 24 * time.Hour
-	case "w":
-		duration = time.Duration(value) * 7 * 24 * time.Hour
-	case "m":
-		duration = time.Duration(value) * 30 * 24 * time.Hour
-	case "y":
-		duration = time.Duration(value) * 365 * 24 * time.Hour
-	default:
-		return time.Time{}, fmt.Errorf("invalid duration unit: %s (use d, w, m, or y)", unit)
+const highlightOn = "\033[1;33m"
+const highlightOff = "\033[0m"
+
+// highlightMatches swaps the search match markers for terminal colors, or drops
+// them when the output is not a terminal.
+func highlightMatches(text string, color bool) string {
+	on, off := "", ""
+	if color {
+		on, off = highlightOn, highlightOff
 	}
 
-	return time.Now().Add(-duration), nil
+	return strings.NewReplacer(note.MatchStart, on, note.MatchEnd, off).Replace(text)
+}
+
+// colors reports whether decorated output goes to a terminal and can be
+// colored.
+func (h *handler) colors() bool {
+	f, ok := h.out.Writer().(*os.File)
+	return ok && isTerminal(f)
 }
 
-func renderMarkdownContent(content string) string {
-	return string(markdown.Render(content, markdownWidth, markdownPad))
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
 }
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/matheuzgomes/Snip/internal/notebook"
+	"github.com/matheuzgomes/Snip/internal/render"
+)
+
+type NotebookHandler struct {
+	store *notebook.Store
+	output
+}
+
+// notebookInfo is the structured form of a notebook in the list.
+type notebookInfo struct {
+	Name    string `json:"name"`
+	DBPath  string `json:"db_path"`
+	Current bool   `json:"current"`
+}
+
+func NewNotebookHandler(store *notebook.Store, out *render.Renderer) *NotebookHandler {
+	return &NotebookHandler{store: store, output: newOutput(out)}
+}
+
+func (n *NotebookHandler) CreateNotebook(name string) error {
+	nb, err := n.store.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create notebook: %w", err)
+	}
+
+	message := fmt.Sprintf("Notebook '%s' created!", nb.Name)
+	return n.report(Result{Status: "created", Location: nb.DBPath, Message: message},
+		"✓ %s\n  Switch to it with: snip notebook use %s\n", message, nb.Name)
+}
+
+func (n *NotebookHandler) ListNotebooks() error {
+	notebooks, err := n.store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list notebooks: %w", err)
+	}
+
+	current, err := n.store.Current()
+	if err != nil {
+		return fmt.Errorf("failed to read current notebook: %w", err)
+	}
+
+	if n.out.Structured() {
+		infos := []notebookInfo{}
+		for _, nb := range notebooks {
+			infos = append(infos, notebookInfo{Name: nb.Name, DBPath: nb.DBPath, Current: nb.Name == current})
+		}
+		return n.out.Render(infos)
+	}
+
+	fmt.Fprintf(n.out, "Found %d notebook(s):\n\n", len(notebooks))
+
+	for _, nb := range notebooks {
+		marker := "○"
+		if nb.Name == current {
+			marker = "●"
+		}
+		fmt.Fprintf(n.out, "%s %s\n", marker, nb.Name)
+		fmt.Fprintf(n.out, "  └─ %s\n", nb.DBPath)
+	}
+
+	return nil
+}
+
+func (n *NotebookHandler) UseNotebook(name string) error {
+	if err := n.store.Use(name); err != nil {
+		return fmt.Errorf("failed to switch notebook: %w", err)
+	}
+
+	message := fmt.Sprintf("Now using notebook '%s'!", name)
+	return n.report(Result{Status: "switched", Message: message}, "✓ %s\n", message)
+}
+
+func (n *NotebookHandler) RemoveNotebook(name string, force bool) error {
+	if _, err := n.store.Get(name); err != nil {
+		return fmt.Errorf("failed to remove notebook: %w", err)
+	}
+
+	if !force {
+		prompt := fmt.Sprintf("Permanently delete notebook '%s' with its notes, backups and exports?", name)
+		if !confirm(prompt) {
+			return n.report(Result{Status: "aborted", Message: "Aborted."}, "Aborted.\n")
+		}
+	}
+
+	if err := n.store.Remove(name); err != nil {
+		return fmt.Errorf("failed to remove notebook: %w", err)
+	}
+
+	message := fmt.Sprintf("Notebook '%s' removed!", name)
+	return n.report(Result{Status: "removed", Message: message}, "✓ %s\n", message)
+}
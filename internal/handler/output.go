@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/render"
+)
+
+// Result is the structured output of a command that changes something instead
+// of listing data.
+type Result struct {
+	Status   string `json:"status"`
+	ID       int    `json:"id,omitempty"`
+	Count    *int   `json:"count,omitempty"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+// WithOutput selects how results are written. Without it the decorated text
+// output is used.
+func WithOutput(out *render.Renderer) Option {
+	return func(h *handler) {
+		h.out = out
+	}
+}
+
+// Quiet discards what commands print, for callers such as the TUI that show
+// the outcome themselves.
+func Quiet() Option {
+	return WithOutput(render.New(render.FormatTable, io.Discard))
+}
+
+// output writes what a handler prints, rendered in the format selected with
+// --output. Every handler embeds it.
+type output struct {
+	out *render.Renderer
+}
+
+func newOutput(out *render.Renderer) output {
+	if out == nil {
+		out = render.New(render.FormatTable, os.Stdout)
+	}
+	return output{out: out}
+}
+
+// printf writes decorated text such as hints and progress, which structured
+// output leaves out.
+func (o output) printf(format string, args ...any) {
+	if !o.out.Structured() {
+		fmt.Fprintf(o.out, format, args...)
+	}
+}
+
+// report renders result in structured output and prints the text otherwise.
+func (o output) report(result Result, format string, args ...any) error {
+	if o.out.Structured() {
+		return o.out.Render(result)
+	}
+
+	fmt.Fprintf(o.out, format, args...)
+	return nil
+}
+
+// listOf makes empty results render as [] rather than null.
+func listOf[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func count(n int) *int {
+	return &n
+}
+
+var matchMarkers = strings.NewReplacer(note.MatchStart, "", note.MatchEnd, "")
+
+// plainResults drops the match markers that the decorated output turns into
+// colors.
+func plainResults(results []*note.SearchResult) []*note.SearchResult {
+	plain := make([]*note.SearchResult, len(results))
+	for i, r := range results {
+		copied := *r
+		copied.Highlight = matchMarkers.Replace(r.Highlight)
+		copied.Snippet = matchMarkers.Replace(r.Snippet)
+		plain[i] = &copied
+	}
+	return plain
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/property"
+)
+
+// SetProperties sets key=value pairs on a note. The type of each value is
+// guessed, see property.Parse.
+func (h *handler) SetProperties(idStr string, pairs []string) error {
+	id, err := h.resolveNote(idStr)
+	if err != nil {
+		return err
+	}
+
+	var properties property.Properties
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid property '%s', use key=value", pair)
+		}
+
+		p, err := property.Parse(strings.TrimSpace(key), value)
+		if err != nil {
+			return err
+		}
+		properties = properties.Set(p)
+	}
+
+	if err := h.noteRepo.SetProperties(id, properties); err != nil {
+		return fmt.Errorf("failed to set properties: %w", err)
+	}
+
+	result := Result{Status: "updated", ID: id, Count: count(len(properties)), Message: "Properties set successfully!"}
+	return h.report(result, "✓ %d property(ies) set on note #%d!\n", len(properties), id)
+}
+
+func (h *handler) UnsetProperties(idStr string, keys []string) error {
+	id, err := h.resolveNote(idStr)
+	if err != nil {
+		return err
+	}
+
+	removed, err := h.noteRepo.UnsetProperties(id, keys)
+	if err != nil {
+		return fmt.Errorf("failed to unset properties: %w", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("note #%d has none of the properties %s", id, strings.Join(keys, ", "))
+	}
+
+	result := Result{Status: "updated", ID: id, Count: count(removed), Message: "Properties removed successfully!"}
+	return h.report(result, "✓ %d property(ies) removed from note #%d!\n", removed, id)
+}
+
+func parseConditions(where []string) ([]*property.Condition, error) {
+	var conditions []*property.Condition
+	var errs []error
+	for _, w := range where {
+		c, err := property.ParseCondition(w)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, errors.Join(errs...)
+}
+
+// filterNotes keeps the notes whose properties meet every condition.
+func filterNotes(notes []*note.NoteWithTags, conditions []*property.Condition) []*note.NoteWithTags {
+	if len(conditions) == 0 {
+		return notes
+	}
+
+	var kept []*note.NoteWithTags
+	for _, n := range notes {
+		matched := true
+		for _, c := range conditions {
+			if !c.Match(n.Properties) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
+
+func formatProperties(properties property.Properties) string {
+	pairs := make([]string, len(properties))
+	for i, p := range properties {
+		if p.Type == property.List {
+			pairs[i] = p.Key + "=[" + p.String() + "]"
+		} else {
+			pairs[i] = p.Key + "=" + p.String()
+		}
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+// minHashPrefix keeps short words from being read as content hashes.
+const minHashPrefix = 4
+
+// AmbiguousNoteError is returned when a reference matches several notes.
+type AmbiguousNoteError struct {
+	Ref        string
+	Candidates []*note.NoteWithTags
+}
+
+func (e *AmbiguousNoteError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "'%s' matches %d notes, use one of:", e.Ref, len(e.Candidates))
+	for _, n := range e.Candidates {
+		fmt.Fprintf(&b, "\n  #%d  %s  %s", n.ID, n.Slug, n.Title)
+	}
+	return b.String()
+}
+
+// resolveNote turns a note reference into its ID. A reference is a numeric ID,
+// a slug, a title or the start of one, or the start of the content hash shown
+// by 'snip show -v'. The first of those kinds with any match wins, so an exact
+// slug is never reported as ambiguous with titles it prefixes.
+func (h *handler) resolveNote(ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return 0, errors.New("no note given")
+	}
+
+	if id, err := strconv.Atoi(ref); err == nil {
+		return id, nil
+	}
+
+	notes, err := h.noteRepo.GetAll(true, 0, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch notes: %w", err)
+	}
+
+	lower := strings.ToLower(ref)
+	matchers := []func(n *note.NoteWithTags) bool{
+		func(n *note.NoteWithTags) bool { return strings.EqualFold(n.Slug, ref) },
+		func(n *note.NoteWithTags) bool { return strings.EqualFold(n.Title, ref) },
+		func(n *note.NoteWithTags) bool { return strings.HasPrefix(strings.ToLower(n.Title), lower) },
+	}
+	if isHashPrefix(lower) {
+		matchers = append(matchers, func(n *note.NoteWithTags) bool {
+			return strings.HasPrefix(note.ContentHash(n.Content), lower)
+		})
+	}
+
+	for _, matches := range matchers {
+		var candidates []*note.NoteWithTags
+		for _, n := range notes {
+			if matches(n) {
+				candidates = append(candidates, n)
+			}
+		}
+
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return candidates[0].ID, nil
+		default:
+			return 0, &AmbiguousNoteError{Ref: ref, Candidates: candidates}
+		}
+	}
+
+	return 0, fmt.Errorf("no note matches '%s' (use an ID, slug, title prefix or content hash prefix)", ref)
+}
+
+// shortHash is the part of a note's content hash shown to users.
+func shortHash(content string) string {
+	return note.ContentHash(content)[:note.ShortHashLength]
+}
+
+func isHashPrefix(s string) bool {
+	if len(s) < minHashPrefix {
+		return false
+	}
+	return strings.IndexFunc(s, func(r rune) bool {
+		return !('0' <= r && r <= '9' || 'a' <= r && r <= 'f')
+	}) == -1
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/repository"
+	"github.com/matheuzgomes/Snip/internal/tag"
+)
+
+func (h *handler) ListTags() error {
+	tags, err := h.tagRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	if h.out.Structured() {
+		return h.out.Render(listOf(tags))
+	}
+
+	if len(tags) == 0 {
+		fmt.Fprintln(h.out, "No tags found.")
+		return nil
+	}
+
+	fmt.Fprintf(h.out, "Found %d tag(s):\n\n", len(tags))
+
+	for _, t := range tags {
+		fmt.Fprintf(h.out, "● %s (%d)\n", t.Name, t.NoteCount)
+	}
+
+	return nil
+}
+
+// ShowTagTree prints the tag hierarchy. Counts include the notes of every
+// descendant tag.
+func (h *handler) ShowTagTree() error {
+	tags, err := h.tagRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	// parent_id already carries the hierarchy
+	if h.out.Structured() {
+		return h.out.Render(listOf(tags))
+	}
+
+	if len(tags) == 0 {
+		fmt.Fprintln(h.out, "No tags found.")
+		return nil
+	}
+
+	children := make(map[int][]*tag.Tag)
+	for _, t := range tags {
+		children[t.ParentID] = append(children[t.ParentID], t)
+	}
+
+	for _, root := range children[0] {
+		fmt.Fprintf(h.out, "● %s (%d)\n", root.Name, root.SubtreeCount)
+		printTagTree(h.out, children, root.ID, "")
+	}
+
+	return nil
+}
+
+func printTagTree(w io.Writer, children map[int][]*tag.Tag, parentID int, indent string) {
+	nodes := children[parentID]
+	for i, t := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		fmt.Fprintf(w, "%s%s%s (%d)\n", indent, branch, t.Leaf(), t.SubtreeCount)
+		printTagTree(w, children, t.ID, indent+next)
+	}
+}
+
+func hasSubtags(tags []*tag.Tag, id int) bool {
+	for _, t := range tags {
+		if t.ParentID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *handler) RenameTag(oldName string, newName string) error {
+	newName = tag.NormalizePath(newName)
+	if newName == "" || strings.ContainsAny(newName, " \t") {
+		return fmt.Errorf("invalid tag name: '%s'", newName)
+	}
+
+	existingTag, err := h.tagRepo.GetByName(oldName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tag '%s': %w", oldName, err)
+	}
+
+	if strings.HasPrefix(strings.ToLower(newName), strings.ToLower(existingTag.Name+tag.Separator)) {
+		return fmt.Errorf("cannot move tag '%s' under itself", existingTag.Name)
+	}
+
+	existing, err := h.tagRepo.GetByName(newName)
+	if err == nil && existing.ID != existingTag.ID {
+		return fmt.Errorf("tag '%s' already exists, use 'snip tag merge %s --into %s' instead", existing.Name, oldName, existing.Name)
+	}
+	if err != nil && !errors.Is(err, repository.ErrTagNotFound) {
+		return fmt.Errorf("failed to fetch tag '%s': %w", newName, err)
+	}
+
+	if err := h.tagRepo.Patch(existingTag.ID, newName); err != nil {
+		return fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	message := fmt.Sprintf("Tag '%s' renamed to '%s'!", existingTag.Name, newName)
+	return h.report(Result{Status: "renamed", ID: existingTag.ID, Message: message}, "✓ %s\n", message)
+}
+
+func (h *handler) MergeTags(sources []string, into string) error {
+	if strings.TrimSpace(into) == "" {
+		return fmt.Errorf("a target tag is required, e.g. --into work")
+	}
+
+	tags, err := h.tagRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	var sourceIDs []int
+	for _, name := range sources {
+		source, err := h.tagRepo.GetByName(name)
+		if err != nil {
+			return fmt.Errorf("failed to fetch tag '%s': %w", name, err)
+		}
+		if hasSubtags(tags, source.ID) {
+			return fmt.Errorf("tag '%s' has subtags, rename it instead to move them", source.Name)
+		}
+		sourceIDs = append(sourceIDs, source.ID)
+	}
+
+	target, err := h.tagRepo.GetOrCreate(into)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tag '%s': %w", into, err)
+	}
+
+	if err := h.tagRepo.Merge(sourceIDs, target.ID); err != nil {
+		return fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	message := fmt.Sprintf("Tags %s merged into '%s'!", strings.Join(sources, ", "), target.Name)
+	return h.report(Result{Status: "merged", ID: target.ID, Message: message}, "✓ %s\n", message)
+}
+
+func (h *handler) DeleteTag(name string) error {
+	existingTag, err := h.tagRepo.GetByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tag '%s': %w", name, err)
+	}
+
+	if err := h.tagRepo.Delete(existingTag.ID); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	message := fmt.Sprintf("Tag '%s' deleted!", existingTag.Name)
+	return h.report(Result{Status: "deleted", ID: existingTag.ID, Message: message}, "✓ %s\n", message)
+}
+
+func (h *handler) PruneTags() error {
+	pruned, err := h.tagRepo.Prune()
+	if err != nil {
+		return fmt.Errorf("failed to prune tags: %w", err)
+	}
+
+	message := fmt.Sprintf("%d unused tag(s) removed!", pruned)
+	return h.report(Result{Status: "pruned", Count: count(int(pruned)), Message: message}, "✓ %s\n", message)
+}
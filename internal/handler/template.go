@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/notetemplate"
+	"github.com/matheuzgomes/Snip/internal/placeholder"
+	"github.com/matheuzgomes/Snip/internal/render"
+)
+
+// templateSkeleton is what 'snip template add' opens in the editor.
+const templateSkeleton = `---
+# description: Blameless postmortem
+# tags: [postmortem]
+# prompts:
+#   - name: service
+#     prompt: Which service was affected?
+#     default: api
+---
+# {{title}}
+
+{{date}} {{time}}, {{user}}
+`
+
+// CreateNoteFromTemplate opens the editor on a new note filled from a
+// template. Variables not given in vars are asked for on the terminal, the
+// template tags are added to tag.
+func (h *handler) CreateNoteFromTemplate(title string, tmpl *notetemplate.Template, vars map[string]string, tag *string) error {
+	if err := h.validator.ValidateNote(title); err != nil {
+		return err
+	}
+
+	body, err := fillTemplate(tmpl, title, vars, time.Now())
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := h.editorHandler.HandleEditor(body)
+	if err != nil {
+		return err
+	}
+	defer h.editorHandler.RemoveTempFile(tempFile)
+
+	content, err := h.editorHandler.ReadTempFile(tempFile)
+	if err != nil {
+		return err
+	}
+
+	tags := strings.Join(tmpl.Tags, " ")
+	if tag != nil && *tag != "" {
+		tags += " " + *tag
+	}
+
+	return h.saveNewNote(title, content, &tags, "")
+}
+
+// fillTemplate expands the variables of a template: the built-in ones, then
+// vars, then the answers to the template prompts.
+func fillTemplate(tmpl *notetemplate.Template, title string, vars map[string]string, now time.Time) (string, error) {
+	values := map[string]string{
+		"date":  now.Format("2006-01-02"),
+		"time":  now.Format("15:04"),
+		"title": title,
+		"user":  currentUser(),
+	}
+	for name, value := range vars {
+		values[name] = value
+	}
+
+	return fillPlaceholders(tmpl.Body, tmpl.Variables(), values, tmpl.Question, "var")
+}
+
+// fillPlaceholders expands text with values, asking for each placeholder that
+// has none. flag names the option that passes values without a terminal.
+func fillPlaceholders(text string, placeholders []placeholder.Placeholder, values map[string]string, question func(string) string, flag string) (string, error) {
+	for _, p := range placeholders {
+		if _, ok := values[p.Name]; ok {
+			continue
+		}
+
+		answer, err := ask(question(p.Name), p.Default, p.HasDefault)
+		if err != nil {
+			return "", fmt.Errorf("no value for '%s', pass --%s %s=value", p.Name, flag, p.Name)
+		}
+		values[p.Name] = answer
+	}
+
+	expanded, _ := placeholder.Expand(text, values)
+	return expanded, nil
+}
+
+// stdin is shared by the prompts, so nothing typed ahead is lost between them.
+var stdin = bufio.NewReader(os.Stdin)
+
+var errNoTerminal = errors.New("no terminal to ask on")
+
+// ask prints a question on stderr and reads the answer from stdin. An empty
+// answer gives the default. Without a terminal it never reads stdin, which may
+// be a pipe holding other input or never closed, and gives the default or
+// fails.
+func ask(question string, def string, hasDefault bool) (string, error) {
+	if !isTerminal(os.Stdin) {
+		if !hasDefault {
+			return "", errNoTerminal
+		}
+		return def, nil
+	}
+
+	if hasDefault {
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: ", question)
+	}
+
+	answer, err := stdin.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer, nil
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr)
+		if !hasDefault {
+			return "", err
+		}
+	}
+	return def, nil
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+type TemplateHandler struct {
+	store         *notetemplate.Store
+	editorHandler *EditorHandler
+	output
+}
+
+// templateInfo is the structured form of a note template.
+type templateInfo struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Tags        []string           `json:"tags"`
+	Variables   []templateVariable `json:"variables"`
+	Path        string             `json:"path"`
+}
+
+type templateVariable struct {
+	Name    string `json:"name"`
+	Default string `json:"default,omitempty"`
+}
+
+func NewTemplateHandler(store *notetemplate.Store, cfg *config.Config, out *render.Renderer) *TemplateHandler {
+	return &TemplateHandler{store: store, editorHandler: NewEditorHandlerFromConfig(cfg), output: newOutput(out)}
+}
+
+func (t *TemplateHandler) info(tmpl *notetemplate.Template) templateInfo {
+	info := templateInfo{
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		Tags:        listOf(tmpl.Tags),
+		Variables:   []templateVariable{},
+		Path:        t.store.Path(tmpl.Name),
+	}
+	for _, v := range tmpl.Variables() {
+		info.Variables = append(info.Variables, templateVariable{Name: v.Name, Default: v.Default})
+	}
+	return info
+}
+
+func (t *TemplateHandler) ListTemplates() error {
+	templates, err := t.store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	infos := []templateInfo{}
+	for _, tmpl := range templates {
+		infos = append(infos, t.info(tmpl))
+	}
+
+	if t.out.Structured() {
+		return t.out.Render(infos)
+	}
+
+	if len(infos) == 0 {
+		fmt.Fprintln(t.out, "No templates found, add one with 'snip template add <name>'.")
+		return nil
+	}
+
+	fmt.Fprintf(t.out, "Found %d template(s) in %s:\n\n", len(infos), t.store.Dir())
+
+	for _, info := range infos {
+		fmt.Fprintf(t.out, "● %s", info.Name)
+		if info.Description != "" {
+			fmt.Fprintf(t.out, "  %s", info.Description)
+		}
+		fmt.Fprintln(t.out)
+
+		if len(info.Tags) > 0 {
+			fmt.Fprintf(t.out, "  └─ Tags: %s\n", strings.Join(info.Tags, ", "))
+		}
+		if len(info.Variables) > 0 {
+			names := make([]string, len(info.Variables))
+			for i, v := range info.Variables {
+				names[i] = v.Name
+			}
+			fmt.Fprintf(t.out, "  └─ Variables: %s\n", strings.Join(names, ", "))
+		}
+	}
+
+	return nil
+}
+
+// AddTemplate saves content as a new template. Without content it opens the
+// editor on a skeleton.
+func (t *TemplateHandler) AddTemplate(name string, content *string) error {
+	if content != nil {
+		tmpl, err := t.store.Create(name, *content)
+		if err != nil {
+			return fmt.Errorf("failed to add template: %w", err)
+		}
+		return t.saved(tmpl, "added")
+	}
+
+	if _, err := t.store.Create(name, templateSkeleton); err != nil {
+		return fmt.Errorf("failed to add template: %w", err)
+	}
+
+	return t.edit(name, "added")
+}
+
+func (t *TemplateHandler) EditTemplate(name string) error {
+	if err := t.store.Exists(name); err != nil {
+		return fmt.Errorf("failed to edit template: %w", err)
+	}
+
+	return t.edit(name, "saved")
+}
+
+func (t *TemplateHandler) edit(name string, action string) error {
+	if err := t.editorHandler.EditFile(t.store.Path(name)); err != nil {
+		return err
+	}
+
+	tmpl, err := t.store.Get(name)
+	if err != nil {
+		return fmt.Errorf("template saved but invalid, fix it with 'snip template edit %s': %w", name, err)
+	}
+
+	return t.saved(tmpl, action)
+}
+
+// saved reports a template that was added or edited, rendering it in
+// structured output.
+func (t *TemplateHandler) saved(tmpl *notetemplate.Template, action string) error {
+	if t.out.Structured() {
+		return t.out.Render(t.info(tmpl))
+	}
+
+	fmt.Fprintf(t.out, "✓ Template '%s' %s!\n", tmpl.Name, action)
+	fmt.Fprintf(t.out, "  Use it with: snip create --template %s \"Title\"\n", tmpl.Name)
+	return nil
+}
+
+func (t *TemplateHandler) RemoveTemplate(name string, force bool) error {
+	if err := t.store.Exists(name); err != nil {
+		return fmt.Errorf("failed to remove template: %w", err)
+	}
+
+	if !force && !confirm(fmt.Sprintf("Delete template '%s'?", name)) {
+		return t.report(Result{Status: "aborted", Message: "Aborted."}, "Aborted.\n")
+	}
+
+	if err := t.store.Remove(name); err != nil {
+		return fmt.Errorf("failed to remove template: %w", err)
+	}
+
+	message := fmt.Sprintf("Template '%s' removed!", name)
+	return t.report(Result{Status: "removed", Location: t.store.Path(name), Message: message}, "✓ %s\n", message)
+}
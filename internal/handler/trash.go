@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/search"
+)
+
+func (h *handler) ListTrash() error {
+	notes, err := h.noteRepo.GetDeleted()
+	if err != nil {
+		return fmt.Errorf("failed to fetch trash: %w", err)
+	}
+
+	if h.out.Structured() {
+		return h.out.Render(listOf(notes))
+	}
+
+	if len(notes) == 0 {
+		fmt.Fprintln(h.out, "Trash is empty.")
+		return nil
+	}
+
+	fmt.Fprintf(h.out, "Found %d note(s) in the trash:\n\n", len(notes))
+
+	for _, note := range notes {
+		tags := strings.Join(note.Tags, ", ")
+		fmt.Fprintf(h.out, "● #%d %s [%s]\n", note.ID, note.Title, tags)
+		fmt.Fprintf(h.out, "  └─ Deleted: %s\n", note.DeletedAt.Format(h.dateFormat))
+		fmt.Fprintln(h.out)
+	}
+
+	return nil
+}
+
+func (h *handler) RestoreNote(idStr string) error {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return fmt.Errorf("invalid note ID: %s", idStr)
+	}
+
+	if err := h.noteRepo.Restore(id); err != nil {
+		return fmt.Errorf("failed to restore note: %w", err)
+	}
+
+	message := fmt.Sprintf("Note #%d restored from the trash!", id)
+	return h.report(Result{Status: "restored", ID: id, Message: message}, "✓ %s\n", message)
+}
+
+func (h *handler) EmptyTrash(olderThan string, force bool) error {
+	var before *time.Time
+	if olderThan != "" {
+		parsed, err := search.ParseSince(olderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than value: %w", err)
+		}
+		before = &parsed
+	}
+
+	if !force {
+		prompt := "Permanently delete every note in the trash?"
+		if before != nil {
+			prompt = fmt.Sprintf("Permanently delete notes trashed before %s?", before.Format(h.dateFormat))
+		}
+		if !confirm(prompt) {
+			return h.report(Result{Status: "aborted", Message: "Aborted."}, "Aborted.\n")
+		}
+	}
+
+	purged, err := h.noteRepo.Purge(before)
+	if err != nil {
+		return fmt.Errorf("failed to empty trash: %w", err)
+	}
+
+	message := fmt.Sprintf("%d note(s) permanently deleted!", purged)
+	return h.report(Result{Status: "purged", Count: count(purged), Message: message}, "✓ %s\n", message)
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no. The prompt
+// goes to stderr so it never mixes with structured output.
+func confirm(prompt string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", prompt)
+
+	answer, err := stdin.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/placeholder"
+)
+
+// UseNote expands the {{name}} and {{name:default}} placeholders of a note
+// with vars, asking for the others, and prints the result. With outFile it is
+// written there instead, with run it is run by the user's shell once
+// confirmed, or straight away with force.
+func (h *handler) UseNote(idStr string, vars map[string]string, outFile string, run bool, force bool) error {
+	id, err := h.resolveNote(idStr)
+	if err != nil {
+		return err
+	}
+
+	n, err := h.noteRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch note: %w", err)
+	}
+
+	text, err := expandSnippet(n.Content, vars)
+	if err != nil {
+		return err
+	}
+
+	if outFile == "" && !run {
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		fmt.Fprint(h.out, text)
+		return nil
+	}
+
+	if outFile != "" {
+		if err := os.WriteFile(outFile, []byte(text), 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		if err := h.report(Result{Status: "written", ID: id, Location: outFile, Message: "Snippet written successfully!"},
+			"✓ Snippet written to %s!\n", outFile); err != nil {
+			return err
+		}
+	}
+
+	if run {
+		return runSnippet(text, force)
+	}
+	return nil
+}
+
+// expandSnippet fills the placeholders of text with vars, then with the
+// answers to a prompt for each one left.
+func expandSnippet(text string, vars map[string]string) (string, error) {
+	values := make(map[string]string, len(vars))
+	for name, value := range vars {
+		values[name] = value
+	}
+
+	return fillPlaceholders(text, placeholder.Find(text), values, func(name string) string { return name }, "set")
+}
+
+// runSnippet runs text with $SHELL -c, /bin/sh when it is not set, after
+// showing it and asking to go on unless force is set.
+func runSnippet(text string, force bool) error {
+	shell, flag := os.Getenv("SHELL"), "-c"
+	if shell == "" {
+		shell = "/bin/sh"
+		if runtime.GOOS == "windows" {
+			shell, flag = "cmd", "/C"
+		}
+	}
+
+	if !force {
+		fmt.Fprintf(os.Stderr, "%s\n", strings.TrimRight(text, "\n"))
+		if !confirm(fmt.Sprintf("Run this with %s?", shell)) {
+			return errors.New("canceled, nothing was run")
+		}
+	}
+
+	cmd := exec.Command(shell, flag, text)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("snippet failed: %w", err)
+	}
+	return nil
+}
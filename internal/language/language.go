@@ -0,0 +1,149 @@
+// Package language names the programming language of a snippet, guesses it
+// from a file name or the content, and highlights code with chroma.
+package language
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/formatters"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+)
+
+// Auto asks for the language to be detected from the content.
+const Auto = "auto"
+
+// None clears the language of a note.
+const None = "none"
+
+// DefaultExtension is used for notes without a language or with one chroma
+// has no file pattern for.
+const DefaultExtension = ".txt"
+
+// prose are the lexers that match text rather than code, a note in one of
+// them has no language.
+var prose = []string{"markdown", "plaintext"}
+
+// fencePattern matches content that is a single fenced code block.
+var fencePattern = regexp.MustCompile("(?s)^\\s*```\\s*([\\w+#.-]+)[^\\n]*\\n.*\\n```\\s*$")
+
+var shebangPattern = regexp.MustCompile(`^#!\s*(?:\S*/)?([\w.+-]+)(?:[ \t]+([\w.+-]+))?`)
+
+// Normalize returns the name a language is stored under, e.g. go for golang
+// or Go, and bash for sh. Extensions work too: py gives python. It returns ""
+// for none.
+func Normalize(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == None {
+		return "", nil
+	}
+
+	lexer := lexers.Get(name)
+	if lexer == nil {
+		return "", fmt.Errorf("unknown language '%s'", name)
+	}
+	return nameOf(lexer), nil
+}
+
+// FromFilename returns the language of a file by its name, or "" when it is
+// not code.
+func FromFilename(filename string) string {
+	// several lexers share some extensions, .sql is MySQL as well as SQL, so
+	// the one named after the extension wins
+	var lexer chroma.Lexer
+	if ext := strings.TrimPrefix(filepath.Ext(filename), "."); ext != "" {
+		lexer = lexers.Get(ext)
+	}
+	if lexer == nil {
+		lexer = lexers.Match(filepath.Base(filename))
+	}
+	if lexer == nil {
+		return ""
+	}
+	return nameOf(lexer)
+}
+
+// Detect guesses the language of content from the info string of a note that
+// is a single fenced block or the interpreter of a #! line. It returns "" when
+// there is neither, chroma's own analysers take prose for code too often.
+func Detect(content string) string {
+	if m := fencePattern.FindStringSubmatch(content); m != nil {
+		if name, err := Normalize(m[1]); err == nil {
+			return name
+		}
+	}
+
+	if m := shebangPattern.FindStringSubmatch(content); m != nil {
+		interpreter := m[1]
+		if interpreter == "env" && m[2] != "" {
+			interpreter = m[2]
+		}
+		if name, err := Normalize(strings.TrimRight(interpreter, "0123456789.")); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// Names lists the languages a note can have, sorted.
+func Names() []string {
+	var names []string
+	for _, lexer := range lexers.Registry.Lexers {
+		if name := nameOf(lexer); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Extension returns the file extension for a language, like .go or .sql.
+func Extension(name string) string {
+	if name == "" {
+		return DefaultExtension
+	}
+
+	lexer := lexers.Get(name)
+	if lexer == nil {
+		return DefaultExtension
+	}
+	for _, pattern := range lexer.Config().Filenames {
+		ext := strings.TrimPrefix(pattern, "*")
+		if strings.HasPrefix(ext, ".") && !strings.ContainsAny(ext, "*?[") {
+			return ext
+		}
+	}
+	return DefaultExtension
+}
+
+// Highlight writes content colored for a terminal as code in the language.
+func Highlight(w io.Writer, content string, name string) error {
+	lexer := lexers.Get(name)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, content)
+	if err != nil {
+		return err
+	}
+	return formatters.TTY256.Format(w, styles.Monokai, iterator)
+}
+
+func nameOf(lexer chroma.Lexer) string {
+	config := lexer.Config()
+
+	if slices.Contains(prose, strings.ToLower(config.Name)) {
+		return ""
+	}
+	if len(config.Aliases) > 0 {
+		return strings.ToLower(config.Aliases[0])
+	}
+	return strings.ToLower(config.Name)
+}
@@ -0,0 +1,75 @@
+package link
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var refPattern = regexp.MustCompile(`\[\[([^\[\]\n]+)\]\]`)
+
+// Ref is a reference written in note content, either [[Note Title]] or [[#42]].
+type Ref struct {
+	// Text is what stands between the brackets, trimmed.
+	Text string
+	// NoteID is set for [[#42]] references and zero for title references.
+	NoteID int
+}
+
+// Link is a reference from one note to another. TargetID is zero when the
+// reference does not point to any note outside the trash.
+type Link struct {
+	SourceID    int    `json:"source_id"`
+	SourceTitle string `json:"source_title"`
+	Ref         string `json:"ref"`
+	TargetID    int    `json:"target_id,omitempty"`
+	TargetTitle string `json:"target_title,omitempty"`
+}
+
+func (l *Link) IsBroken() bool {
+	return l.TargetID == 0
+}
+
+// IsByTitle reports whether the link was written as [[Note Title]].
+func (l *Link) IsByTitle() bool {
+	return !strings.HasPrefix(l.Ref, "#")
+}
+
+// Parse returns the distinct references in content, in order of appearance.
+// Title references are compared case-insensitively.
+func Parse(content string) []Ref {
+	var refs []Ref
+	seen := make(map[string]bool)
+
+	for _, match := range refPattern.FindAllStringSubmatch(content, -1) {
+		text := strings.TrimSpace(match[1])
+		if text == "" || seen[strings.ToLower(text)] {
+			continue
+		}
+		seen[strings.ToLower(text)] = true
+
+		ref := Ref{Text: text}
+		if id, err := strconv.Atoi(strings.TrimPrefix(text, "#")); err == nil && strings.HasPrefix(text, "#") && id > 0 {
+			ref.NoteID = id
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// RewriteTitle replaces every [[oldTitle]] reference in content with
+// [[newTitle]] and returns the new content and the number of replacements.
+func RewriteTitle(content, oldTitle, newTitle string) (string, int) {
+	count := 0
+	rewritten := refPattern.ReplaceAllStringFunc(content, func(match string) string {
+		text := strings.TrimSpace(match[2 : len(match)-2])
+		if !strings.EqualFold(text, strings.TrimSpace(oldTitle)) {
+			return match
+		}
+		count++
+		return "[[" + newTitle + "]]"
+	})
+
+	return rewritten, count
+}
@@ -0,0 +1,25 @@
+package note
+
+import "strings"
+
+const frontMatterFence = "---"
+
+// SplitFrontMatter separates a YAML front matter block, fenced by "---" lines
+// at the very start of text, from the body that follows it. ok is false when
+// text has no front matter, body is then the whole text.
+func SplitFrontMatter(text string) (front string, body string, ok bool) {
+	firstLine, rest, found := strings.Cut(text, "\n")
+	if !found || strings.TrimRight(firstLine, "\r") != frontMatterFence {
+		return "", text, false
+	}
+
+	offset := 0
+	for _, line := range strings.SplitAfter(rest, "\n") {
+		if strings.TrimRight(line, "\r\n") == frontMatterFence {
+			return rest[:offset], rest[offset+len(line):], true
+		}
+		offset += len(line)
+	}
+
+	return "", text, false
+}
@@ -1,22 +1,44 @@
 package note
 
-import "time"
+import (
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/property"
+)
 
 type Note struct {
 	ID        int       `json:"id"`
 	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
 	Content   string    `json:"content"`
+	Language  string    `json:"language,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// JournalDate is the day, as 2006-01-02, of a journal note.
+	JournalDate string `json:"journal_date,omitempty"`
 }
 
 type NoteWithTags struct {
-	ID        int       `json:"id"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	Tags      []string  `json:"tags"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         int                 `json:"id"`
+	Title      string              `json:"title"`
+	Slug       string              `json:"slug"`
+	Content    string              `json:"content"`
+	Language   string              `json:"language,omitempty"`
+	Tags       []string            `json:"tags"`
+	Properties property.Properties `json:"properties,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+	DeletedAt  *time.Time          `json:"deleted_at,omitempty"`
+}
+
+// Edit holds the fields of a note that are changed together from the editor.
+type Edit struct {
+	Title      string
+	Slug       string
+	Content    string
+	Language   string
+	Tags       []string
+	Properties property.Properties
 }
 
 func NewNote(title, content string) *Note {
@@ -28,3 +50,17 @@ func NewNote(title, content string) *Note {
 		UpdatedAt: now,
 	}
 }
+
+// Markers wrapped around matched terms in SearchResult.Highlight and
+// SearchResult.Snippet. Callers replace them with whatever fits their output.
+const (
+	MatchStart = "\x02"
+	MatchEnd   = "\x03"
+)
+
+type SearchResult struct {
+	Note
+	Highlight string  `json:"highlight"`
+	Snippet   string  `json:"snippet"`
+	Rank      float64 `json:"rank"`
+}
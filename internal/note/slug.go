@@ -0,0 +1,53 @@
+package note
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+)
+
+const maxSlugLength = 50
+
+// ShortHashLength is how much of a content hash is shown to users.
+const ShortHashLength = 7
+
+// Slugify turns a title into a slug such as "meeting-notes-2025". Like file
+// names on export, anything but letters and digits becomes a separator and the
+// result is capped at 50 characters. Slugs made only of digits get a "note-"
+// prefix so they never read as an ID.
+func Slugify(title string) string {
+	var b strings.Builder
+	separate := false
+	for _, r := range strings.ToLower(title) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = b.Len() > 0
+			continue
+		}
+		if separate {
+			b.WriteByte('-')
+			separate = false
+		}
+		b.WriteRune(r)
+	}
+
+	slug := []rune(b.String())
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+	}
+	result := strings.TrimRight(string(slug), "-")
+
+	if result == "" {
+		return "note"
+	}
+	if strings.IndexFunc(result, func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+		return "note-" + result
+	}
+	return result
+}
+
+// ContentHash identifies a note by its content, as a hex encoded SHA-256.
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,245 @@
+package notebook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultName is the notebook that lives directly in ~/.snip, where snip kept
+// its database before notebooks existed.
+const DefaultName = "default"
+
+const (
+	dbFile       = "notes.db"
+	notebooksDir = "notebooks"
+	currentFile  = "notebook"
+)
+
+var (
+	ErrNotFound      = errors.New("notebook not found")
+	ErrAlreadyExists = errors.New("notebook already exists")
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Notebook is a separate set of notes with its own database, backups and
+// exports.
+type Notebook struct {
+	Name string
+	// Dir holds the backups and exports of the notebook.
+	Dir    string
+	DBPath string
+}
+
+func (n *Notebook) BackupDir() string {
+	return filepath.Join(n.Dir, "backups")
+}
+
+func (n *Notebook) ExportDir() string {
+	return filepath.Join(n.Dir, "export")
+}
+
+// Store manages the notebooks kept under the snip directory:
+//
+//	~/.snip/notes.db                  default notebook
+//	~/.snip/notebooks/<name>/notes.db other notebooks
+//	~/.snip/notebook                  name of the notebook in use
+type Store struct {
+	root string
+}
+
+func NewStore(root string) *Store {
+	return &Store{root: root}
+}
+
+// DefaultStore returns the store rooted at ~/.snip.
+func DefaultStore() (*Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return NewStore(filepath.Join(homeDir, ".snip")), nil
+}
+
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid notebook name '%s' (use letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+func (s *Store) dir(name string) string {
+	if name == DefaultName {
+		return s.root
+	}
+	return filepath.Join(s.root, notebooksDir, name)
+}
+
+func (s *Store) notebook(name string) *Notebook {
+	dir := s.dir(name)
+	return &Notebook{Name: name, Dir: dir, DBPath: filepath.Join(dir, dbFile)}
+}
+
+// Get returns an existing notebook. The default notebook always exists.
+func (s *Store) Get(name string) (*Notebook, error) {
+	if name == DefaultName {
+		if err := os.MkdirAll(s.root, 0755); err != nil {
+			return nil, err
+		}
+		return s.notebook(name), nil
+	}
+
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(s.dir(name)); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: '%s'", ErrNotFound, name)
+		}
+		return nil, err
+	}
+
+	return s.notebook(name), nil
+}
+
+// List returns the default notebook followed by the others in name order.
+func (s *Store) List() ([]*Notebook, error) {
+	notebooks := []*Notebook{s.notebook(DefaultName)}
+
+	entries, err := os.ReadDir(filepath.Join(s.root, notebooksDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		notebooks = append(notebooks, s.notebook(name))
+	}
+
+	return notebooks, nil
+}
+
+func (s *Store) Create(name string) (*Notebook, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	if name == DefaultName {
+		return nil, fmt.Errorf("%w: '%s'", ErrAlreadyExists, name)
+	}
+
+	if _, err := os.Stat(s.dir(name)); err == nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrAlreadyExists, name)
+	}
+
+	if err := os.MkdirAll(s.dir(name), 0755); err != nil {
+		return nil, err
+	}
+
+	return s.notebook(name), nil
+}
+
+// Remove deletes a notebook with its database, backups and exports. When it
+// was the notebook in use, the default notebook is used again.
+func (s *Store) Remove(name string) error {
+	if name == DefaultName {
+		return fmt.Errorf("the default notebook cannot be removed")
+	}
+
+	nb, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(nb.Dir); err != nil {
+		return err
+	}
+
+	current, err := s.Current()
+	if err != nil {
+		return err
+	}
+	if current == name {
+		return s.Use(DefaultName)
+	}
+
+	return nil
+}
+
+// Current returns the name of the notebook selected with Use.
+func (s *Store) Current() (string, error) {
+	data, err := os.ReadFile(filepath.Join(s.root, currentFile))
+	if os.IsNotExist(err) {
+		return DefaultName, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.TrimSpace(string(data))
+	if name == "" {
+		return DefaultName, nil
+	}
+	return name, nil
+}
+
+func (s *Store) Use(name string) error {
+	if _, err := s.Get(name); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.root, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(s.root, currentFile), []byte(name+"\n"), 0644)
+}
+
+// Resolve picks the notebook to work in: the given name (from --notebook)
+// first, then a database path in SNIP_DB, then the notebook selected with Use.
+func (s *Store) Resolve(name string) (*Notebook, error) {
+	if name != "" {
+		return s.Get(name)
+	}
+
+	if path := os.Getenv("SNIP_DB"); path != "" {
+		return FromPath(path)
+	}
+
+	current, err := s.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(current)
+}
+
+// FromPath wraps a database file outside the store. Backups and exports go
+// next to it.
+func FromPath(path string) (*Notebook, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Notebook{Name: path, Dir: dir, DBPath: path}, nil
+}
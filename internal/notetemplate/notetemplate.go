@@ -0,0 +1,243 @@
+// Package notetemplate manages the templates new notes can start from, such
+// as a meeting, postmortem or ADR layout.
+package notetemplate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/placeholder"
+	"gopkg.in/yaml.v3"
+)
+
+// Ext is the extension of template files.
+const Ext = ".md"
+
+var (
+	ErrNotFound      = errors.New("template not found")
+	ErrAlreadyExists = errors.New("template already exists")
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Builtins are the variables snip fills in itself.
+var Builtins = []string{"date", "time", "title", "user"}
+
+// Prompt describes a variable the user is asked for when the template is used.
+type Prompt struct {
+	Name     string `yaml:"name"`
+	Question string `yaml:"prompt"`
+	Default  string `yaml:"default"`
+}
+
+// Template is a note layout. It is a markdown file with an optional front
+// matter block:
+//
+//	---
+//	description: Blameless postmortem
+//	tags: [postmortem, incident]
+//	prompts:
+//	  - name: service
+//	    prompt: Which service was affected?
+//	    default: api
+//	---
+//	# {{title}}
+//
+//	{{date}}, {{user}}
+type Template struct {
+	Name        string   `yaml:"-"`
+	Description string   `yaml:"description"`
+	Tags        []string `yaml:"tags"`
+	Prompts     []Prompt `yaml:"prompts"`
+	Body        string   `yaml:"-"`
+}
+
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid template name '%s' (use letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+// Parse reads a template file.
+func Parse(name string, data string) (*Template, error) {
+	t := &Template{Name: name, Body: data}
+
+	front, body, ok := note.SplitFrontMatter(data)
+	if !ok {
+		return t, nil
+	}
+
+	if err := yaml.Unmarshal([]byte(front), t); err != nil {
+		return nil, fmt.Errorf("invalid front matter in template '%s': %w", name, err)
+	}
+	for _, p := range t.Prompts {
+		if p.Name == "" {
+			return nil, fmt.Errorf("invalid front matter in template '%s': every prompt needs a name", name)
+		}
+	}
+	t.Body = body
+
+	return t, nil
+}
+
+// Variables returns the variables of the body that snip does not fill in
+// itself, with the default given by their prompt or placeholder.
+func (t *Template) Variables() []placeholder.Placeholder {
+	var variables []placeholder.Placeholder
+	for _, p := range placeholder.Find(t.Body) {
+		if slices.Contains(Builtins, p.Name) {
+			continue
+		}
+		if prompt := t.prompt(p.Name); prompt != nil && prompt.Default != "" {
+			p.Default = prompt.Default
+			p.HasDefault = true
+		}
+		variables = append(variables, p)
+	}
+	return variables
+}
+
+// Question returns what to ask the user for a variable.
+func (t *Template) Question(name string) string {
+	if prompt := t.prompt(name); prompt != nil && prompt.Question != "" {
+		return prompt.Question
+	}
+	return name
+}
+
+func (t *Template) prompt(name string) *Prompt {
+	for i := range t.Prompts {
+		if t.Prompts[i].Name == name {
+			return &t.Prompts[i]
+		}
+	}
+	return nil
+}
+
+// Store keeps templates as <name>.md files in a directory, by default
+// ~/.snip/note-templates next to the config file.
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Path returns the file of a template, whether it exists or not.
+func (s *Store) Path(name string) string {
+	return filepath.Join(s.dir, name+Ext)
+}
+
+// Exists returns an error when there is no template with that name. Unlike
+// Get it does not parse the file, so broken templates can still be fixed.
+func (s *Store) Exists(name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(s.Path(name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: '%s'", ErrNotFound, name)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) Get(name string) (*Template, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(s.Path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: '%s'", ErrNotFound, name)
+		}
+		return nil, err
+	}
+
+	return Parse(name, string(data))
+}
+
+// List returns the templates in name order.
+func (s *Store) List() ([]*Template, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), Ext)
+		if ok && !entry.IsDir() && ValidateName(name) == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var templates []*Template
+	for _, name := range names {
+		t, err := s.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+
+	return templates, nil
+}
+
+// Create saves a new template after checking that it parses.
+func (s *Store) Create(name string, content string) (*Template, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(s.Path(name)); err == nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrAlreadyExists, name)
+	}
+
+	t, err := Parse(name, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(s.Path(name), []byte(content), 0644); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *Store) Remove(name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.Path(name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: '%s'", ErrNotFound, name)
+		}
+		return err
+	}
+
+	return nil
+}
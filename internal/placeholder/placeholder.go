@@ -0,0 +1,74 @@
+// Package placeholder finds and fills the {{name}} and {{name:default}}
+// placeholders used in note templates.
+package placeholder
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var pattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*(?::([^{}]*))?\}\}`)
+
+// Placeholder is a variable found in a text.
+type Placeholder struct {
+	Name string
+	// Default is used when no value is given, it is set with {{name:default}}.
+	Default    string
+	HasDefault bool
+}
+
+// Find returns the placeholders of text in the order they first appear. When
+// a name is used several times, the first default given for it wins.
+func Find(text string) []Placeholder {
+	var placeholders []Placeholder
+	seen := map[string]int{}
+
+	for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		name := text[match[2]:match[3]]
+		hasDefault := match[4] >= 0
+
+		if i, ok := seen[name]; ok {
+			if hasDefault && !placeholders[i].HasDefault {
+				placeholders[i].Default = strings.TrimSpace(text[match[4]:match[5]])
+				placeholders[i].HasDefault = true
+			}
+			continue
+		}
+
+		p := Placeholder{Name: name, HasDefault: hasDefault}
+		if hasDefault {
+			p.Default = strings.TrimSpace(text[match[4]:match[5]])
+		}
+		seen[name] = len(placeholders)
+		placeholders = append(placeholders, p)
+	}
+
+	return placeholders
+}
+
+// Expand fills every placeholder with its value, or its default when values
+// has none. It returns the names left without a value, their placeholders are
+// kept as they are.
+func Expand(text string, values map[string]string) (string, []string) {
+	var missing []string
+
+	expanded := pattern.ReplaceAllStringFunc(text, func(match string) string {
+		sub := pattern.FindStringSubmatchIndex(match)
+		name := match[sub[2]:sub[3]]
+
+		if value, ok := values[name]; ok {
+			return value
+		}
+		if sub[4] >= 0 {
+			return strings.TrimSpace(match[sub[4]:sub[5]])
+		}
+
+		if !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+		return match
+	})
+
+	return expanded, missing
+}
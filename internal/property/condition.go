@@ -0,0 +1,107 @@
+package property
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var conditionPattern = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_-]*)\s*(?:(>=|<=|!=|=|>|<)\s*(.*?))?\s*$`)
+
+// Condition filters notes on a property, e.g. priority>=2, status!=done or
+// just due to keep the notes that have one.
+type Condition struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+func ParseCondition(s string) (*Condition, error) {
+	m := conditionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("invalid condition '%s', use key, key=value, key!=value or key>=value (also >, <, <=)", s)
+	}
+
+	c := &Condition{Key: m[1], Op: m[2], Value: strings.Trim(m[3], `"'`)}
+	if c.Op != "" && c.Value == "" {
+		return nil, fmt.Errorf("invalid condition '%s', no value to compare with", s)
+	}
+	if strings.IndexAny(c.Value, "<>=!") == 0 {
+		return nil, fmt.Errorf("invalid condition '%s', use a single operator", s)
+	}
+	return c, nil
+}
+
+func (c *Condition) String() string {
+	return c.Key + c.Op + c.Value
+}
+
+// Match reports whether properties meet the condition. The value is compared
+// as the type of the property: numbers and dates by order, lists by what they
+// contain, strings ignoring case. A note without the property only matches !=.
+func (c *Condition) Match(properties Properties) bool {
+	p := properties.Get(c.Key)
+	if p == nil {
+		return c.Op == "!="
+	}
+	if c.Op == "" {
+		return true
+	}
+
+	switch p.Type {
+	case Number:
+		want, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil {
+			return c.Op == "!="
+		}
+		got, _ := strconv.ParseFloat(p.Value, 64)
+		return compare(c.Op, cmp.Compare(got, want))
+	case Date:
+		want, err := time.Parse(DateLayout, c.Value)
+		if err != nil {
+			return c.Op == "!="
+		}
+		return compare(c.Op, strings.Compare(p.Value, want.Format(DateLayout)))
+	case Bool:
+		want, err := strconv.ParseBool(c.Value)
+		if err != nil || (c.Op != "=" && c.Op != "!=") {
+			return false
+		}
+		return (p.Value == strconv.FormatBool(want)) == (c.Op == "=")
+	case List:
+		contains := slices.ContainsFunc(p.Items(), func(item string) bool {
+			return strings.EqualFold(item, c.Value)
+		})
+		switch c.Op {
+		case "=":
+			return contains
+		case "!=":
+			return !contains
+		}
+		return false
+	default:
+		return compare(c.Op, strings.Compare(strings.ToLower(p.Value), strings.ToLower(c.Value)))
+	}
+}
+
+func compare(op string, result int) bool {
+	switch op {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+// Package property holds the typed key/value fields a note can carry, such as
+// status=done or priority=2, and the conditions used to filter on them.
+package property
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Type string
+
+const (
+	String Type = "string"
+	Number Type = "number"
+	Date   Type = "date"
+	Bool   Type = "bool"
+	List   Type = "list"
+)
+
+// DateLayout is how date properties are written.
+const DateLayout = "2006-01-02"
+
+// Reserved are the note fields that share the front matter with properties.
+var Reserved = []string{"title", "slug", "tags", "language"}
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+var numberPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Property is a typed field of a note. Value is kept as text: numbers in their
+// shortest form, dates as 2006-01-02, bools as true or false and lists as a
+// JSON array.
+type Property struct {
+	Key   string
+	Type  Type
+	Value string
+}
+
+func ValidateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid property name '%s' (use letters, digits, '-' and '_')", key)
+	}
+	if slices.Contains(Reserved, key) {
+		return fmt.Errorf("'%s' is a note field, not a property", key)
+	}
+	return nil
+}
+
+// Parse reads a value typed on the command line and guesses its type:
+// true/false are bools, 2 or 2.5 numbers, 2025-03-14 dates and [a, b] lists.
+// Anything else, or a quoted value, is a string.
+func Parse(key string, raw string) (*Property, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("no value for '%s'", key)
+	}
+
+	if len(raw) >= 2 && (raw[0] == '"' || raw[0] == '\'') && raw[len(raw)-1] == raw[0] {
+		return &Property{Key: key, Type: String, Value: raw[1 : len(raw)-1]}, nil
+	}
+
+	if strings.HasPrefix(raw, "[") && strings.HasSuffix(raw, "]") {
+		var items []string
+		for item := range strings.SplitSeq(raw[1:len(raw)-1], ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return newList(key, items)
+	}
+
+	return infer(key, raw), nil
+}
+
+// FromValue turns a value decoded from JSON or YAML into a property.
+func FromValue(key string, value any) (*Property, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case string:
+		if _, err := time.Parse(DateLayout, v); err == nil {
+			return &Property{Key: key, Type: Date, Value: v}, nil
+		}
+		return &Property{Key: key, Type: String, Value: v}, nil
+	case bool:
+		return &Property{Key: key, Type: Bool, Value: strconv.FormatBool(v)}, nil
+	case int:
+		return &Property{Key: key, Type: Number, Value: strconv.Itoa(v)}, nil
+	case float64:
+		return &Property{Key: key, Type: Number, Value: strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case time.Time:
+		return &Property{Key: key, Type: Date, Value: v.Format(DateLayout)}, nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return newList(key, items)
+	case nil:
+		return nil, fmt.Errorf("no value for '%s'", key)
+	default:
+		return nil, fmt.Errorf("unsupported value for '%s', use a string, number, date, bool or list", key)
+	}
+}
+
+func infer(key string, raw string) *Property {
+	switch {
+	case raw == "true" || raw == "false":
+		return &Property{Key: key, Type: Bool, Value: raw}
+	case numberPattern.MatchString(raw):
+		f, _ := strconv.ParseFloat(raw, 64)
+		return &Property{Key: key, Type: Number, Value: strconv.FormatFloat(f, 'f', -1, 64)}
+	}
+
+	if _, err := time.Parse(DateLayout, raw); err == nil {
+		return &Property{Key: key, Type: Date, Value: raw}
+	}
+	return &Property{Key: key, Type: String, Value: raw}
+}
+
+func newList(key string, items []string) (*Property, error) {
+	if items == nil {
+		items = []string{}
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return &Property{Key: key, Type: List, Value: string(data)}, nil
+}
+
+// Items returns the elements of a list property.
+func (p *Property) Items() []string {
+	var items []string
+	json.Unmarshal([]byte(p.Value), &items)
+	return items
+}
+
+// Interface returns the value as a Go value, for JSON and YAML output.
+func (p *Property) Interface() any {
+	switch p.Type {
+	case Number:
+		f, _ := strconv.ParseFloat(p.Value, 64)
+		return f
+	case Bool:
+		return p.Value == "true"
+	case List:
+		return p.Items()
+	default:
+		return p.Value
+	}
+}
+
+func (p *Property) String() string {
+	if p.Type == List {
+		return strings.Join(p.Items(), ", ")
+	}
+	return p.Value
+}
+
+// Properties are the properties of a note, in key order. In JSON they are an
+// object of typed values.
+type Properties []*Property
+
+func (ps Properties) Get(key string) *Property {
+	for _, p := range ps {
+		if p.Key == key {
+			return p
+		}
+	}
+	return nil
+}
+
+// Set adds p, or replaces the property with the same key, keeping key order.
+func (ps Properties) Set(p *Property) Properties {
+	i, found := slices.BinarySearchFunc(ps, p.Key, func(e *Property, key string) int {
+		return strings.Compare(e.Key, key)
+	})
+	if found {
+		ps[i] = p
+		return ps
+	}
+	return slices.Insert(ps, i, p)
+}
+
+func (ps Properties) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, p := range ps {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(p.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(p.Interface())
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func (ps *Properties) UnmarshalJSON(data []byte) error {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	properties, err := FromMap(values)
+	if err != nil {
+		return err
+	}
+	*ps = properties
+	return nil
+}
+
+// MarshalYAML writes properties as a mapping of typed values, with lists in
+// flow style so each property stays on one line.
+func (ps Properties) MarshalYAML() (any, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	for _, p := range ps {
+		value := &yaml.Node{}
+		if err := value.Encode(p.Interface()); err != nil {
+			return nil, err
+		}
+		if value.Kind == yaml.SequenceNode {
+			value.Style = yaml.FlowStyle
+		}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: p.Key}, value)
+	}
+	return mapping, nil
+}
+
+// FromMap turns values decoded from JSON or YAML into properties.
+func FromMap(values map[string]any) (Properties, error) {
+	var properties Properties
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		p, err := FromValue(key, values[key])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		properties = properties.Set(p)
+	}
+	return properties, errors.Join(errs...)
+}
@@ -0,0 +1,227 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	// FormatTable is the decorated, human readable output.
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatTSV   Format = "tsv"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case "", FormatTable:
+		return FormatTable, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatYAML, "yml":
+		return FormatYAML, nil
+	case FormatTSV:
+		return FormatTSV, nil
+	}
+	return "", fmt.Errorf("invalid output format '%s' (use table, json, yaml or tsv)", value)
+}
+
+// Renderer writes command results in a machine-readable format, or passes the
+// decorated text of the table format through as an io.Writer. Field names and
+// order follow the json tags of the rendered values in every format.
+type Renderer struct {
+	format Format
+	out    io.Writer
+	tmpl   *template.Template
+}
+
+func New(format Format, out io.Writer) *Renderer {
+	return &Renderer{format: format, out: out}
+}
+
+func (r *Renderer) Format() Format {
+	return r.format
+}
+
+// Writer returns where the output goes.
+func (r *Renderer) Writer() io.Writer {
+	return r.out
+}
+
+// Write writes decorated text, what the table format prints.
+func (r *Renderer) Write(p []byte) (int, error) {
+	return r.out.Write(p)
+}
+
+// Structured reports whether results must be rendered instead of printed as
+// decorated text.
+func (r *Renderer) Structured() bool {
+	return r.format != FormatTable
+}
+
+// Render writes v, a struct, a map or a slice of them.
+func (r *Renderer) Render(v any) error {
+	if r.tmpl != nil {
+		return r.writeTemplate(v)
+	}
+
+	data, err := marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to render output: %w", err)
+	}
+
+	switch r.format {
+	case FormatYAML:
+		return r.writeYAML(data)
+	case FormatTSV:
+		return r.writeTSV(data)
+	default:
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, data, "", "  "); err != nil {
+			return err
+		}
+		indented.WriteByte('\n')
+		_, err := r.out.Write(indented.Bytes())
+		return err
+	}
+}
+
+// marshal is json.Marshal without escaping <, > and &, which show up in notes
+// and error messages.
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// ErrorResult is how a failed command is reported in structured output.
+type ErrorResult struct {
+	Error string `json:"error"`
+}
+
+func (r *Renderer) Error(err error) error {
+	return r.Render(ErrorResult{Error: err.Error()})
+}
+
+// JSON is valid YAML, so decoding it into a node keeps the json field names
+// and their order. Styles are reset so the encoder writes block YAML.
+func (r *Renderer) writeYAML(data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	resetStyle(&doc)
+
+	encoder := yaml.NewEncoder(r.out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// writeTSV writes one row per object with a header listing every field in the
+// order they first appear. Lists are joined with commas and nested objects written as
+// JSON.
+func (r *Renderer) writeTSV(data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	root := doc.Content[0]
+	rows := []*yaml.Node{root}
+	if root.Kind == yaml.SequenceNode {
+		rows = root.Content
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if rows[0].Kind != yaml.MappingNode {
+		for _, row := range rows {
+			if _, err := fmt.Fprintln(r.out, tsvValue(row)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// omitempty fields are missing from some rows
+	var header []string
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		for i := 0; i < len(row.Content); i += 2 {
+			if key := row.Content[i].Value; !seen[key] {
+				seen[key] = true
+				header = append(header, key)
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(header, "\t") + "\n")
+
+	for _, row := range rows {
+		values := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(row.Content); i += 2 {
+			values[row.Content[i].Value] = row.Content[i+1]
+		}
+
+		cells := make([]string, len(header))
+		for i, key := range header {
+			if value, ok := values[key]; ok {
+				cells[i] = tsvValue(value)
+			}
+		}
+		b.WriteString(strings.Join(cells, "\t") + "\n")
+	}
+
+	_, err := io.WriteString(r.out, b.String())
+	return err
+}
+
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func tsvValue(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return ""
+		}
+		return tsvEscaper.Replace(node.Value)
+	case yaml.SequenceNode:
+		items := make([]string, len(node.Content))
+		for i, item := range node.Content {
+			items[i] = tsvValue(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		var decoded any
+		if err := node.Decode(&decoded); err != nil {
+			return ""
+		}
+		data, _ := marshal(decoded)
+		return tsvEscaper.Replace(string(data))
+	}
+}
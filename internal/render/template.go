@@ -0,0 +1,161 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+// FormatTemplate renders results with a user supplied text/template.
+const FormatTemplate Format = "template"
+
+// TemplateExt is the extension of named templates in the templates directory.
+const TemplateExt = ".tmpl"
+
+// TemplateOptions carries the settings the template helpers fall back to.
+type TemplateOptions struct {
+	DateFormat    string
+	MarkdownWidth int
+}
+
+// Funcs returns the helpers available in templates:
+//
+//	join .Tags ","          joins a list
+//	date .CreatedAt         formats a time with date_format, or a given layout
+//	truncate 40 .Content    cuts text to n characters, ending with "..."
+//	wrap 80 .Content        wraps text at n columns
+//	oneline .Content        puts text on a single line
+//	markdown .Content       renders markdown for the terminal
+func Funcs(opts TemplateOptions) template.FuncMap {
+	return template.FuncMap{
+		"join": func(items []string, sep string) string {
+			return strings.Join(items, sep)
+		},
+		"date": func(t time.Time, layout ...string) string {
+			if len(layout) > 0 {
+				return t.Format(layout[0])
+			}
+			return t.Format(opts.DateFormat)
+		},
+		"truncate": func(n int, s string) string {
+			runes := []rune(s)
+			if n <= 0 || len(runes) <= n {
+				return s
+			}
+			if n <= 3 {
+				return string(runes[:n])
+			}
+			return string(runes[:n-3]) + "..."
+		},
+		"wrap": func(n uint, s string) string {
+			return wordwrap.WrapString(s, n)
+		},
+		"oneline": func(s string) string {
+			return strings.Join(strings.Fields(s), " ")
+		},
+		"markdown": func(s string) string {
+			return string(markdown.Render(s, opts.MarkdownWidth, 0))
+		},
+	}
+}
+
+var escapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// ParseTemplate parses an inline template. The \t and \n escapes are expanded
+// in the text between actions since shells pass them through quoted arguments
+// untouched. Inside an action they are string escapes already: {{"\n"}}.
+func ParseTemplate(text string, opts TemplateOptions) (*template.Template, error) {
+	text = expandEscapes(text)
+
+	tmpl, err := template.New("inline").Funcs(Funcs(opts)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func expandEscapes(text string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			b.WriteString(escapes.Replace(text))
+			return b.String()
+		}
+		b.WriteString(escapes.Replace(text[:start]))
+
+		end := strings.Index(text[start:], "}}")
+		if end < 0 {
+			b.WriteString(text[start:])
+			return b.String()
+		}
+		end += start + len("}}")
+		b.WriteString(text[start:end])
+		text = text[end:]
+	}
+}
+
+// LoadTemplate resolves value as an inline template when it contains an
+// action, or else as the name of a template file in dir.
+func LoadTemplate(dir, value string, opts TemplateOptions) (*template.Template, error) {
+	if strings.Contains(value, "{{") {
+		return ParseTemplate(value, opts)
+	}
+
+	path := filepath.Join(dir, value+TemplateExt)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("template '%s' not found, create %s", value, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	tmpl, err := template.New(value).Funcs(Funcs(opts)).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template '%s': %w", value, err)
+	}
+	return tmpl, nil
+}
+
+// NewTemplate returns a renderer that executes tmpl once for every item of a
+// list, or once for a single value.
+func NewTemplate(tmpl *template.Template, out io.Writer) *Renderer {
+	return &Renderer{format: FormatTemplate, out: out, tmpl: tmpl}
+}
+
+// writeTemplate ends every execution with a newline unless the template
+// already does.
+func (r *Renderer) writeTemplate(v any) error {
+	items := []any{v}
+	if value := reflect.ValueOf(v); value.Kind() == reflect.Slice {
+		items = make([]any, value.Len())
+		for i := range items {
+			items[i] = value.Index(i).Interface()
+		}
+	}
+
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := r.tmpl.Execute(&buf, item); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := r.out.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+// GetJournal returns the journal note of a day, given as 2006-01-02, or nil
+// when there is none yet. A note in the trash is returned with DeletedAt set,
+// since it still holds the day.
+func (r *repository) GetJournal(date string) (*note.NoteWithTags, error) {
+	query := `
+		SELECT n.id, n.title, COALESCE(n.slug, ''), n.content, n.created_at, n.updated_at, n.deleted_at, GROUP_CONCAT(t.name) AS tags
+		FROM notes n
+		LEFT JOIN notes_tags nt ON n.id = nt.note_id
+		LEFT JOIN tags t ON nt.tag_id = t.id
+		WHERE n.journal_date = ?
+		GROUP BY n.id
+	`
+
+	note := &note.NoteWithTags{}
+	var deletedAt sql.NullTime
+	var tagsStr sql.NullString
+
+	err := r.db.QueryRow(query, date).Scan(
+		&note.ID, &note.Title, &note.Slug, &note.Content, &note.CreatedAt, &note.UpdatedAt, &deletedAt, &tagsStr,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if deletedAt.Valid {
+		note.DeletedAt = &deletedAt.Time
+	}
+
+	note.Tags = []string{}
+
+	if tagsStr.Valid && tagsStr.String != "" {
+		note.Tags = strings.Split(tagsStr.String, ",")
+	}
+
+	return note, nil
+}
+
+// GetJournals returns the latest journal notes outside the trash, newest day
+// first.
+func (r *repository) GetJournals(limit int) ([]*note.NoteWithTags, error) {
+	query := `
+		SELECT n.id, n.title, COALESCE(n.slug, ''), n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
+		FROM notes n
+		LEFT JOIN notes_tags nt ON n.id = nt.note_id
+		LEFT JOIN tags t ON nt.tag_id = t.id
+		WHERE n.journal_date IS NOT NULL AND n.deleted_at IS NULL
+		GROUP BY n.id
+		ORDER BY n.journal_date DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []*note.NoteWithTags
+	for rows.Next() {
+		note := &note.NoteWithTags{}
+		var tagsStr sql.NullString
+		err := rows.Scan(&note.ID, &note.Title, &note.Slug, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr)
+		if err != nil {
+			return nil, err
+		}
+
+		note.Tags = []string{}
+
+		if tagsStr.Valid && tagsStr.String != "" {
+			note.Tags = strings.Split(tagsStr.String, ",")
+		}
+
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/matheuzgomes/Snip/internal/link"
+)
+
+const linkColumns = `
+	SELECT r.source_id, s.title, r.ref, COALESCE(r.target_id, 0), COALESCE(t.title, '')
+	FROM resolved_links r
+	INNER JOIN notes s ON s.id = r.source_id AND s.deleted_at IS NULL
+	LEFT JOIN notes t ON t.id = r.target_id
+`
+
+// SaveLinks replaces the outgoing links of a note with refs.
+func (r *repository) SaveLinks(noteID int, refs []link.Ref) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveLinks(tx, noteID, refs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveLinks(db execQuerier, noteID int, refs []link.Ref) error {
+	if _, err := db.Exec(`DELETE FROM note_links WHERE source_id = ?`, noteID); err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		query := `INSERT OR IGNORE INTO note_links (source_id, ref, target_id) VALUES (?, ?, NULLIF(?, 0))`
+		if _, err := db.Exec(query, noteID, ref.Text, ref.NoteID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLinks returns the links written in a note.
+func (r *repository) GetLinks(noteID int) ([]*link.Link, error) {
+	return r.queryLinks(linkColumns+` WHERE r.source_id = ? ORDER BY r.ref`, noteID)
+}
+
+// GetBacklinks returns the links of other notes pointing to a note.
+func (r *repository) GetBacklinks(noteID int) ([]*link.Link, error) {
+	return r.queryLinks(linkColumns+` WHERE r.target_id = ? ORDER BY r.source_id`, noteID)
+}
+
+// GetAllLinks returns every link written in a note outside the trash.
+func (r *repository) GetAllLinks() ([]*link.Link, error) {
+	return r.queryLinks(linkColumns + ` ORDER BY r.source_id, r.ref`)
+}
+
+func (r *repository) queryLinks(query string, args ...any) ([]*link.Link, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*link.Link
+	for rows.Next() {
+		l := &link.Link{}
+		err := rows.Scan(&l.SourceID, &l.SourceTitle, &l.Ref, &l.TargetID, &l.TargetTitle)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+
+	return links, rows.Err()
+}
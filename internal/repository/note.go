@@ -10,27 +10,61 @@ import (
 	"strings"
 	"time"
 
+	"github.com/matheuzgomes/Snip/internal/language"
+	"github.com/matheuzgomes/Snip/internal/link"
 	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/property"
+	"github.com/matheuzgomes/Snip/internal/revision"
+	"github.com/matheuzgomes/Snip/internal/search"
 	"github.com/matheuzgomes/Snip/internal/tag"
+	"gopkg.in/yaml.v3"
 )
 
+// ErrSlugTaken is returned when another note, even one in the trash, already
+// uses a slug.
+var ErrSlugTaken = errors.New("slug is already used by another note")
+
 type NoteRepository interface {
 	Create(note *note.Note) error
 	GetByID(id int) (*note.NoteWithTags, error)
-	GetAll(isAsc bool, tagID int) ([]*note.NoteWithTags, error)
+	GetAll(isAsc bool, tagID int, withSubtags bool) ([]*note.NoteWithTags, error)
 	Update(id int, content string, title string) error
+	Append(id int, text string) error
+	Prepend(id int, text string) error
+	GetJournal(date string) (*note.NoteWithTags, error)
+	GetJournals(limit int) ([]*note.NoteWithTags, error)
 	Delete(id int) error
-	Search(term string) ([]*note.Note, error)
+	Search(query *search.Query) ([]*note.SearchResult, error)
 	CheckByID(id int) error
 	Patch(id int, title string) error
+	SetLanguage(id int, language string) error
+	ApplyEdit(id int, edit *note.Edit) error
+	SetProperties(noteID int, properties property.Properties) error
+	UnsetProperties(noteID int, keys []string) (int, error)
 	GetRecent(limit int) ([]*note.NoteWithTags, error)
-	ExportNotes(exportDir string, since *time.Time, format string) error
+	GetDeleted() ([]*note.NoteWithTags, error)
+	Restore(id int) error
+	Purge(before *time.Time) (int, error)
+	ExportNotes(exportDir string, since *time.Time, format string) (int, error)
 
 	// Tag operations
 	AddTagToNote(noteID, tagID int) error
 	RemoveTagFromNote(noteID int) error
 	GetTagsByNote(noteID int) ([]*tag.Tag, error)
 
+	// Link operations
+	SaveLinks(noteID int, refs []link.Ref) error
+	GetLinks(noteID int) ([]*link.Link, error)
+	GetBacklinks(noteID int) ([]*link.Link, error)
+	GetAllLinks() ([]*link.Link, error)
+
+	// Revision operations
+	SaveRevision(noteID int) error
+	GetRevisions(noteID int) ([]*revision.Revision, error)
+	GetRevision(noteID, number int) (*revision.Revision, error)
+	PruneRevisions(noteID, keep int) error
+	RevertNote(noteID int, rev *revision.Revision, refs []link.Ref, keep int) error
+
 	Close() error
 }
 
@@ -47,12 +81,17 @@ func (r *repository) Close() error {
 }
 
 func (r *repository) Create(note *note.Note) error {
+	slug, err := uniqueSlug(r.db, note.Title)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO notes (title, content, created_at, updated_at)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO notes (title, slug, content, language, created_at, updated_at, journal_date)
+		VALUES (?, ?, ?, ?, ?, ?, NULLIF(?, ''))
 	`
 
-	result, err := r.db.Exec(query, note.Title, note.Content, note.CreatedAt, note.UpdatedAt)
+	result, err := r.db.Exec(query, note.Title, slug, note.Content, note.Language, note.CreatedAt, note.UpdatedAt, note.JournalDate)
 	if err != nil {
 		return err
 	}
@@ -63,23 +102,43 @@ func (r *repository) Create(note *note.Note) error {
 	}
 
 	note.ID = int(id)
+	note.Slug = slug
 	return nil
 }
 
+// uniqueSlug derives a slug from title, numbering it when another note, even
+// one in the trash, already uses it. Slugs are kept when a note is renamed so
+// references to them stay valid.
+func uniqueSlug(db execQuerier, title string) (string, error) {
+	base := note.Slugify(title)
+	slug := base
+
+	for n := 2; ; n++ {
+		var exists bool
+		if err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM notes WHERE slug = ?)`, slug).Scan(&exists); err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
 func (r *repository) GetByID(id int) (*note.NoteWithTags, error) {
 	query := `
-		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
+		SELECT n.id, n.title, COALESCE(n.slug, ''), n.content, n.language, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
 		FROM notes n
 		LEFT JOIN notes_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
-		WHERE n.id = ?
+		WHERE n.id = ? AND n.deleted_at IS NULL
 	`
 
 	note := &note.NoteWithTags{}
 	var tagsStr sql.NullString
 
 	err := r.db.QueryRow(query, id).Scan(
-		&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr,
+		&note.ID, &note.Title, &note.Slug, &note.Content, &note.Language, &note.CreatedAt, &note.UpdatedAt, &tagsStr,
 	)
 
 	if err != nil {
@@ -95,11 +154,16 @@ func (r *repository) GetByID(id int) (*note.NoteWithTags, error) {
 		note.Tags = strings.Split(tagsStr.String, ",")
 	}
 
+	note.Properties, err = getProperties(r.db, note.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return note, nil
 }
 
 func (r *repository) CheckByID(id int) error {
-	query := `SELECT id FROM notes WHERE id = ?`
+	query := `SELECT id FROM notes WHERE id = ? AND deleted_at IS NULL`
 
 	if err := r.db.QueryRow(query, id).Scan(&id); err != nil {
 		if err == sql.ErrNoRows {
@@ -111,7 +175,9 @@ func (r *repository) CheckByID(id int) error {
 	return nil
 }
 
-func (r *repository) GetAll(isAsc bool, tagID int) ([]*note.NoteWithTags, error) {
+// GetAll lists notes outside the trash, optionally only those carrying tagID
+// or, withSubtags, any of its descendant tags.
+func (r *repository) GetAll(isAsc bool, tagID int, withSubtags bool) ([]*note.NoteWithTags, error) {
 
 	orderBy := "DESC"
 
@@ -122,15 +188,21 @@ func (r *repository) GetAll(isAsc bool, tagID int) ([]*note.NoteWithTags, error)
 	args := []any{}
 
 	query := `
-		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
+		SELECT n.id, n.title, COALESCE(n.slug, ''), n.content, n.language, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
 		FROM notes n
 		LEFT JOIN notes_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
+		WHERE n.deleted_at IS NULL
 		`
 
 	if tagID != 0 {
-		query += `WHERE nt.tag_id = ?`
+		tagFilter := `SELECT ?`
 		args = append(args, tagID)
+		if withSubtags {
+			tagFilter += ` UNION SELECT id FROM (` + subtreeQuery + `)`
+			args = append(args, tagID)
+		}
+		query += ` AND n.id IN (SELECT note_id FROM notes_tags WHERE tag_id IN (` + tagFilter + `))`
 	}
 
 	query += ` GROUP BY n.id`
@@ -147,7 +219,7 @@ func (r *repository) GetAll(isAsc bool, tagID int) ([]*note.NoteWithTags, error)
 	for db.Next() {
 		note := &note.NoteWithTags{}
 		var tagsStr sql.NullString
-		err := db.Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr)
+		err := db.Scan(&note.ID, &note.Title, &note.Slug, &note.Content, &note.Language, &note.CreatedAt, &note.UpdatedAt, &tagsStr)
 		if err != nil {
 			return nil, err
 		}
@@ -161,6 +233,10 @@ func (r *repository) GetAll(isAsc bool, tagID int) ([]*note.NoteWithTags, error)
 		notes = append(notes, note)
 	}
 
+	if err := attachProperties(r.db, notes); err != nil {
+		return nil, err
+	}
+
 	return notes, nil
 }
 
@@ -187,36 +263,60 @@ func (r *repository) Update(id int, content string, title string) error {
 	return err
 }
 
-func (r *repository) Delete(id int) error {
-	query := `DELETE FROM notes WHERE id = ?`
-	_, err := r.db.Exec(query, id)
-	return err
+// Append adds text at the end of a note, starting a new line if the note does
+// not end with one. It is a single UPDATE, so concurrent appends never lose
+// each other's text, and the FTS triggers index the result.
+func (r *repository) Append(id int, text string) error {
+	query := `
+		UPDATE notes
+		SET content = CASE
+				WHEN content = '' OR substr(content, -1) = char(10) THEN content || ?
+				ELSE content || char(10) || ?
+			END,
+			updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	return r.updateContent(query, text, text, time.Now(), id)
 }
 
-func (r *repository) Search(term string) ([]*note.Note, error) {
+// Prepend adds text at the start of a note, on lines of its own.
+func (r *repository) Prepend(id int, text string) error {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
 	query := `
-		SELECT n.id, n.title, n.content
-		FROM notes_fts n
-		WHERE notes_fts MATCH ?
+		UPDATE notes
+		SET content = ? || content, updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
-	db, err := r.db.Query(query, term)
+	return r.updateContent(query, text, time.Now(), id)
+}
+
+func (r *repository) updateContent(query string, args ...any) error {
+	result, err := r.db.Exec(query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer db.Close()
 
-	var notes []*note.Note
-	for db.Next() {
-		note := &note.Note{}
-		err := db.Scan(&note.ID, &note.Title, &note.Content)
-		if err != nil {
-			return nil, err
-		}
-		notes = append(notes, note)
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.New("not found")
 	}
 
-	return notes, nil
+	return nil
+}
+
+// Delete moves a note to the trash. Use Purge to remove it for good.
+func (r *repository) Delete(id int) error {
+	query := `UPDATE notes SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
+	_, err := r.db.Exec(query, time.Now(), id)
+	return err
 }
 
 func (r *repository) AddTagToNote(noteID, tagID int) error {
@@ -269,12 +369,73 @@ func (r *repository) Patch(id int, title string) error {
 	return nil
 }
 
+// SetLanguage sets the code language of a note, "" for none.
+func (r *repository) SetLanguage(id int, language string) error {
+	query := `UPDATE notes SET language = ? WHERE id = ?`
+	_, err := r.db.Exec(query, language, id)
+	return err
+}
+
+// ApplyEdit saves the title, slug, content, language, tags and properties of a
+// note in one transaction, so an edit that fails changes nothing.
+func (r *repository) ApplyEdit(id int, edit *note.Edit) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var taken bool
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM notes WHERE slug = ? AND id != ?)`, edit.Slug, id).Scan(&taken); err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("%w: '%s'", ErrSlugTaken, edit.Slug)
+	}
+
+	query := `UPDATE notes SET title = ?, slug = ?, content = ?, language = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+	result, err := tx.Exec(query, edit.Title, edit.Slug, edit.Content, edit.Language, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.New("not found")
+	}
+
+	if _, err := tx.Exec(`DELETE FROM notes_tags WHERE note_id = ?`, id); err != nil {
+		return err
+	}
+	for _, name := range edit.Tags {
+		t, err := getOrCreateTag(tx, name)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO notes_tags (note_id, tag_id) VALUES (?, ?)`, id, t.ID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM note_properties WHERE note_id = ?`, id); err != nil {
+		return err
+	}
+	if err := insertProperties(tx, id, edit.Properties); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *repository) GetRecent(limit int) ([]*note.NoteWithTags, error) {
 	query := `
-		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
+		SELECT n.id, n.title, COALESCE(n.slug, ''), n.content, n.language, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
 		FROM notes n
 		LEFT JOIN notes_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
+		WHERE n.deleted_at IS NULL
 		GROUP BY n.id
 		ORDER BY n.updated_at DESC
 		LIMIT ?
@@ -291,7 +452,7 @@ func (r *repository) GetRecent(limit int) ([]*note.NoteWithTags, error) {
 	for db.Next() {
 		note := &note.NoteWithTags{}
 		var tagsStr sql.NullString
-		err := db.Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr)
+		err := db.Scan(&note.ID, &note.Title, &note.Slug, &note.Content, &note.Language, &note.CreatedAt, &note.UpdatedAt, &tagsStr)
 		if err != nil {
 			return nil, err
 		}
@@ -305,15 +466,22 @@ func (r *repository) GetRecent(limit int) ([]*note.NoteWithTags, error) {
 		notes = append(notes, note)
 	}
 
+	if err := attachProperties(r.db, notes); err != nil {
+		return nil, err
+	}
+
 	return notes, nil
 }
 
-func (r *repository) ExportNotes(exportDir string, since *time.Time, format string) error {
+// ExportNotes writes every note outside the trash, or those created since a
+// time, to exportDir and returns how many were written.
+func (r *repository) ExportNotes(exportDir string, since *time.Time, format string) (int, error) {
 	query := `
 		SELECT 
 			n.id,
 			n.title,
 			n.content,
+			n.language,
 			n.created_at,
 			n.updated_at,
 			GROUP_CONCAT(t.name) as tags
@@ -322,9 +490,11 @@ func (r *repository) ExportNotes(exportDir string, since *time.Time, format stri
 		LEFT JOIN tags t ON nt.tag_id = t.id
 	`
 
+	query += " WHERE n.deleted_at IS NULL"
+
 	var args []any
 	if since != nil {
-		query += " WHERE n.created_at >= ?"
+		query += " AND n.created_at >= ?"
 		args = append(args, *since)
 	}
 
@@ -333,24 +503,31 @@ func (r *repository) ExportNotes(exportDir string, since *time.Time, format stri
 		ORDER BY n.id
 	`
 
+	properties, err := queryProperties(r.db, "")
+	if err != nil {
+		return 0, err
+	}
+
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer rows.Close()
 
+	exported := 0
 	for rows.Next() {
 		var (
 			id        int
 			title     string
 			content   string
+			language  string
 			createdAt time.Time
 			updatedAt time.Time
 			tagsStr   sql.NullString
 		)
 
-		if err := rows.Scan(&id, &title, &content, &createdAt, &updatedAt, &tagsStr); err != nil {
-			return err
+		if err := rows.Scan(&id, &title, &content, &language, &createdAt, &updatedAt, &tagsStr); err != nil {
+			return 0, err
 		}
 
 		var tags []string
@@ -359,31 +536,37 @@ func (r *repository) ExportNotes(exportDir string, since *time.Time, format stri
 		}
 
 		exportNote := note.NoteWithTags{
-			ID:        id,
-			Title:     title,
-			Content:   content,
-			Tags:      tags,
-			CreatedAt: createdAt,
-			UpdatedAt: updatedAt,
+			ID:         id,
+			Title:      title,
+			Content:    content,
+			Language:   language,
+			Tags:       tags,
+			Properties: properties[id],
+			CreatedAt:  createdAt,
+			UpdatedAt:  updatedAt,
 		}
 
 		switch format {
 		case "json":
 			if err := writeJsonNotesToFile(exportNote, exportDir); err != nil {
-				return err
+				return 0, err
 			}
 		case "markdown":
 			if err := writeMarkdownNotesToFile(exportNote, exportDir); err != nil {
-				return err
+				return 0, err
+			}
+		case "raw":
+			if err := writeRawNoteToFile(exportNote, exportDir); err != nil {
+				return 0, err
 			}
 		default:
-			return fmt.Errorf("invalid format: %s", format)
+			return 0, fmt.Errorf("invalid format: %s", format)
 		}
 
-		fmt.Printf("✓ Note %d exported successfully!\n", id)
+		exported++
 	}
 
-	return rows.Err()
+	return exported, rows.Err()
 }
 
 func writeJsonNotesToFile(note note.NoteWithTags, exportDir string) error {
@@ -414,8 +597,30 @@ func writeMarkdownNotesToFile(note note.NoteWithTags, exportDir string) error {
 	}
 	defer f.Close()
 
+	var front []byte
+	if note.Language != "" {
+		front, err = yaml.Marshal(map[string]string{"language": note.Language})
+		if err != nil {
+			return err
+		}
+	}
+	if len(note.Properties) > 0 {
+		properties, err := yaml.Marshal(note.Properties)
+		if err != nil {
+			return err
+		}
+		front = append(front, properties...)
+	}
+	if len(front) > 0 {
+		fmt.Fprintf(f, "---\n%s---\n", front)
+	}
+
 	fmt.Fprintf(f, "# %s\n\n", note.Title)
-	fmt.Fprintf(f, "%s\n\n", note.Content)
+	if note.Language != "" {
+		fmt.Fprintf(f, "```%s\n%s\n```\n\n", note.Language, strings.TrimRight(note.Content, "\n"))
+	} else {
+		fmt.Fprintf(f, "%s\n\n", note.Content)
+	}
 	if len(note.Tags) > 0 {
 		fmt.Fprintf(f, "**Tags:** %s\n", strings.Join(note.Tags, ", "))
 	}
@@ -425,6 +630,16 @@ func writeMarkdownNotesToFile(note note.NoteWithTags, exportDir string) error {
 	return nil
 }
 
+// writeRawNoteToFile writes the content alone, named with the extension of the
+// note's language so a snippet can be run or opened as the source it is.
+func writeRawNoteToFile(note note.NoteWithTags, exportDir string) error {
+	ext := language.Extension(note.Language)
+	name := sanitizeFilename(strings.TrimSuffix(note.Title, ext))
+	filename := fmt.Sprintf("%d_%s%s", note.ID, name, ext)
+
+	return os.WriteFile(filepath.Join(exportDir, filename), []byte(note.Content), 0644)
+}
+
 func sanitizeFilename(title string) string {
 	title = strings.ReplaceAll(title, "/", "_")
 	title = strings.ReplaceAll(title, "\\", "_")
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/property"
+)
+
+// SetProperties adds properties to a note, replacing the ones with the same
+// key.
+func (r *repository) SetProperties(noteID int, properties property.Properties) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertProperties(tx, noteID, properties); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UnsetProperties removes properties from a note and returns how many it had.
+func (r *repository) UnsetProperties(noteID int, keys []string) (int, error) {
+	removed := 0
+	for _, key := range keys {
+		result, err := r.db.Exec(`DELETE FROM note_properties WHERE note_id = ? AND key = ?`, noteID, key)
+		if err != nil {
+			return removed, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return removed, err
+		}
+		removed += int(affected)
+	}
+	return removed, nil
+}
+
+func insertProperties(db execQuerier, noteID int, properties property.Properties) error {
+	query := `INSERT OR REPLACE INTO note_properties (note_id, key, type, value) VALUES (?, ?, ?, ?)`
+	for _, p := range properties {
+		if _, err := db.Exec(query, noteID, p.Key, string(p.Type), p.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getProperties returns the properties of a note in key order.
+func getProperties(db execQuerier, noteID int) (property.Properties, error) {
+	byNote, err := queryProperties(db, `WHERE note_id = ?`, noteID)
+	if err != nil {
+		return nil, err
+	}
+	return byNote[noteID], nil
+}
+
+// attachProperties fills in the properties of notes with one query.
+func attachProperties(db execQuerier, notes []*note.NoteWithTags) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	byNote, err := queryProperties(db, "")
+	if err != nil {
+		return err
+	}
+	for _, n := range notes {
+		n.Properties = byNote[n.ID]
+	}
+	return nil
+}
+
+func queryProperties(db execQuerier, where string, args ...any) (map[int]property.Properties, error) {
+	rows, err := db.Query(`SELECT note_id, key, type, value FROM note_properties `+where+` ORDER BY note_id, key`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byNote := make(map[int]property.Properties)
+	for rows.Next() {
+		var noteID int
+		p := &property.Property{}
+		if err := rows.Scan(&noteID, &p.Key, &p.Type, &p.Value); err != nil {
+			return nil, err
+		}
+		byNote[noteID] = append(byNote[noteID], p)
+	}
+
+	return byNote, rows.Err()
+}
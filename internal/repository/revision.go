@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/link"
+	"github.com/matheuzgomes/Snip/internal/revision"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// SaveRevision snapshots the current title, content and tags of a note. Nothing
+// is written when the note is unchanged since its latest revision.
+func (r *repository) SaveRevision(noteID int) error {
+	return saveRevision(r.db, noteID)
+}
+
+func saveRevision(db execQuerier, noteID int) error {
+	query := `
+		WITH current AS (
+			SELECT n.id, n.title, n.content,
+				COALESCE((
+					SELECT GROUP_CONCAT(name) FROM (
+						SELECT t.name FROM notes_tags nt
+						INNER JOIN tags t ON t.id = nt.tag_id
+						WHERE nt.note_id = n.id
+						ORDER BY t.name
+					)
+				), '') AS tags
+			FROM notes n
+			WHERE n.id = ?
+		),
+		latest AS (
+			SELECT title, content, tags FROM note_revisions
+			WHERE note_id = ?
+			ORDER BY revision DESC
+			LIMIT 1
+		)
+		INSERT INTO note_revisions (note_id, revision, title, content, tags, created_at)
+		SELECT c.id,
+			COALESCE((SELECT MAX(revision) FROM note_revisions WHERE note_id = c.id), 0) + 1,
+			c.title, c.content, c.tags, ?
+		FROM current c
+		WHERE NOT EXISTS (
+			SELECT 1 FROM latest l
+			WHERE l.title = c.title AND l.content = c.content AND l.tags = c.tags
+		)
+	`
+
+	_, err := db.Exec(query, noteID, noteID, time.Now())
+	return err
+}
+
+// RevertNote restores the title, content and tags of a revision, saves the
+// links of the restored content and records the result as a new revision,
+// keeping the latest keep, all in one transaction so a failed revert changes
+// nothing.
+func (r *repository) RevertNote(noteID int, rev *revision.Revision, refs []link.Ref, keep int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE notes SET title = ?, content = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+	result, err := tx.Exec(query, rev.Title, rev.Content, time.Now(), noteID)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.New("not found")
+	}
+
+	if _, err := tx.Exec(`DELETE FROM notes_tags WHERE note_id = ?`, noteID); err != nil {
+		return err
+	}
+	for _, name := range rev.Tags {
+		t, err := getOrCreateTag(tx, name)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO notes_tags (note_id, tag_id) VALUES (?, ?)`, noteID, t.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := saveLinks(tx, noteID, refs); err != nil {
+		return err
+	}
+	if err := saveRevision(tx, noteID); err != nil {
+		return err
+	}
+	if err := pruneRevisions(tx, noteID, keep); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *repository) GetRevisions(noteID int) ([]*revision.Revision, error) {
+	query := `
+		SELECT id, note_id, revision, title, content, tags, created_at
+		FROM note_revisions
+		WHERE note_id = ?
+		ORDER BY revision DESC
+	`
+
+	rows, err := r.db.Query(query, noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*revision.Revision
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (r *repository) GetRevision(noteID, number int) (*revision.Revision, error) {
+	query := `
+		SELECT id, note_id, revision, title, content, tags, created_at
+		FROM note_revisions
+		WHERE note_id = ? AND revision = ?
+	`
+
+	rev, err := scanRevision(r.db.QueryRow(query, noteID, number))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// PruneRevisions keeps only the latest keep revisions of a note. A keep of zero
+// or less keeps everything.
+func (r *repository) PruneRevisions(noteID, keep int) error {
+	return pruneRevisions(r.db, noteID, keep)
+}
+
+func pruneRevisions(db execQuerier, noteID, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	query := `
+		DELETE FROM note_revisions
+		WHERE note_id = ?
+		AND revision <= (SELECT MAX(revision) FROM note_revisions WHERE note_id = ?) - ?
+	`
+
+	_, err := db.Exec(query, noteID, noteID, keep)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRevision(row rowScanner) (*revision.Revision, error) {
+	rev := &revision.Revision{}
+	var tagsStr string
+
+	err := row.Scan(&rev.ID, &rev.NoteID, &rev.Number, &rev.Title, &rev.Content, &tagsStr, &rev.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rev.Tags = []string{}
+	if tagsStr != "" {
+		rev.Tags = strings.Split(tagsStr, ",")
+	}
+
+	return rev, nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/search"
+)
+
+func (r *repository) Search(query *search.Query) ([]*note.SearchResult, error) {
+	where, args, rankMatch := compileSearch(query)
+
+	// Title hits weigh more than content hits. bm25() is lower for better
+	// matches, so ascending order puts the best result first. Notes selected
+	// only by filters (tag:, created:, ...) have no rank and keep their title.
+	selectQuery := `
+		SELECT n.id, n.title, COALESCE(n.slug, ''), n.content, n.created_at, n.updated_at,
+			n.title, substr(n.content, 1, 200), 0 AS rank
+		FROM notes n
+	`
+	var selectArgs []any
+
+	if rankMatch != "" {
+		selectQuery = `
+			SELECT n.id, n.title, COALESCE(n.slug, ''), n.content, n.created_at, n.updated_at,
+				COALESCE(m.highlight, n.title),
+				COALESCE(m.snippet, substr(n.content, 1, 200)),
+				COALESCE(m.rank, 0) AS rank
+			FROM notes n
+			LEFT JOIN (
+				SELECT rowid,
+					highlight(notes_fts, 0, ?, ?) AS highlight,
+					snippet(notes_fts, 1, ?, ?, '…', 16) AS snippet,
+					bm25(notes_fts, 10.0, 1.0) AS rank
+				FROM notes_fts
+				WHERE notes_fts MATCH ?
+			) m ON m.rowid = n.id
+		`
+		selectArgs = []any{note.MatchStart, note.MatchEnd, note.MatchStart, note.MatchEnd, rankMatch}
+	}
+
+	selectQuery += ` WHERE n.deleted_at IS NULL`
+	if where != "" {
+		selectQuery += ` AND ` + where
+	}
+	selectQuery += ` ORDER BY rank, n.updated_at DESC`
+
+	rows, err := r.db.Query(selectQuery, append(selectArgs, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*note.SearchResult
+	for rows.Next() {
+		result := &note.SearchResult{}
+		err := rows.Scan(
+			&result.ID, &result.Title, &result.Slug, &result.Content, &result.CreatedAt, &result.UpdatedAt,
+			&result.Highlight, &result.Snippet, &result.Rank,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// compileSearch turns a parsed query into a WHERE clause over `notes n` plus
+// its arguments. User input only ever reaches SQL as a bound parameter. It also
+// returns an FTS expression of every positive text term, used for ranking.
+func compileSearch(query *search.Query) (string, []any, string) {
+	var conditions []string
+	var args []any
+	var rankTerms []string
+
+	for _, group := range query.Groups {
+		var alternatives []string
+		for _, term := range group.Terms {
+			condition, termArgs := compileTerm(term)
+			alternatives = append(alternatives, condition)
+			args = append(args, termArgs...)
+
+			if term.IsText() && !term.Negated {
+				rankTerms = append(rankTerms, ftsExpression(term))
+			}
+		}
+
+		if len(alternatives) == 1 {
+			conditions = append(conditions, alternatives[0])
+		} else {
+			conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args, strings.Join(rankTerms, " OR ")
+}
+
+func compileTerm(term search.Term) (string, []any) {
+	in := "IN"
+	if term.Negated {
+		in = "NOT IN"
+	}
+
+	switch term.Kind {
+	case search.KindTag:
+		return `n.id ` + in + ` (
+			SELECT nt.note_id FROM notes_tags nt
+			INNER JOIN tags t ON t.id = nt.tag_id
+			WHERE t.name = ?
+		)`, []any{term.Value}
+	case search.KindCreated, search.KindUpdated:
+		column := "n.created_at"
+		if term.Kind == search.KindUpdated {
+			column = "n.updated_at"
+		}
+
+		var bounds []string
+		var args []any
+		if term.From != nil {
+			bounds = append(bounds, column+" >= ?")
+			args = append(args, *term.From)
+		}
+		if term.To != nil {
+			bounds = append(bounds, column+" < ?")
+			args = append(args, *term.To)
+		}
+
+		condition := "(" + strings.Join(bounds, " AND ") + ")"
+		if term.Negated {
+			condition = "NOT " + condition
+		}
+		return condition, args
+	default:
+		return `n.id ` + in + ` (SELECT rowid FROM notes_fts WHERE notes_fts MATCH ?)`, []any{ftsExpression(term)}
+	}
+}
+
+// ftsExpression quotes a text term as an FTS5 string so that its content is
+// never read as query syntax.
+func ftsExpression(term search.Term) string {
+	expression := `"` + strings.ReplaceAll(term.Value, `"`, `""`) + `"`
+	if term.Prefix {
+		expression += "*"
+	}
+
+	switch term.Kind {
+	case search.KindTitle:
+		return "title : " + expression
+	case search.KindContent:
+		return "content : " + expression
+	default:
+		return expression
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/matheuzgomes/Snip/internal/tag"
 )
@@ -16,6 +18,9 @@ type TagRepository interface {
 	GetAll() ([]*tag.Tag, error)
 	Delete(id int) error
 	GetOrCreate(name string) (*tag.Tag, error)
+	Patch(id int, name string) error
+	Merge(sourceIDs []int, targetID int) error
+	Prune() (int, error)
 	Close() error
 }
 
@@ -23,6 +28,24 @@ type tagRepository struct {
 	db *sql.DB
 }
 
+// execQuerier is satisfied by both *sql.DB and *sql.Tx.
+type execQuerier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// subtreeQuery selects the ids of every descendant of the tag bound to its
+// single parameter, not including the tag itself.
+const subtreeQuery = `
+	WITH RECURSIVE subtree(id) AS (
+		SELECT id FROM tags WHERE parent_id = ?
+		UNION ALL
+		SELECT t.id FROM tags t JOIN subtree s ON t.parent_id = s.id
+	)
+	SELECT id FROM subtree
+`
+
 func NewTagRepository(db *sql.DB) (TagRepository, error) {
 	return &tagRepository{db: db}, nil
 }
@@ -32,9 +55,13 @@ func (r *tagRepository) Close() error {
 }
 
 func (r *tagRepository) Create(tag *tag.Tag) error {
-	query := `INSERT INTO tags (name) VALUES (?)`
+	return createTag(r.db, tag)
+}
 
-	result, err := r.db.Exec(query, tag.Name)
+func createTag(db execQuerier, tag *tag.Tag) error {
+	query := `INSERT INTO tags (name, parent_id) VALUES (?, NULLIF(?, 0))`
+
+	result, err := db.Exec(query, tag.Name, tag.ParentID)
 	if err != nil {
 		return err
 	}
@@ -49,10 +76,15 @@ func (r *tagRepository) Create(tag *tag.Tag) error {
 }
 
 func (r *tagRepository) GetByName(name string) (*tag.Tag, error) {
-	query := `SELECT id, name FROM tags WHERE name = ?`
+	return getTagByName(r.db, name)
+}
+
+func getTagByName(db execQuerier, name string) (*tag.Tag, error) {
+	query := `SELECT id, name, COALESCE(parent_id, 0) FROM tags WHERE name = ?`
+	path := tag.NormalizePath(name)
 
 	tag := &tag.Tag{}
-	err := r.db.QueryRow(query, name).Scan(&tag.ID, &tag.Name)
+	err := db.QueryRow(query, path).Scan(&tag.ID, &tag.Name, &tag.ParentID)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -64,18 +96,69 @@ func (r *tagRepository) GetByName(name string) (*tag.Tag, error) {
 	return tag, nil
 }
 
+// Patch renames a tag and moves its whole subtree along with it, so renaming
+// "work" to "job" turns "work/infra" into "job/infra". Missing ancestors of
+// the new name are created.
 func (r *tagRepository) Patch(id int, name string) error {
-	query := `UPDATE tags SET name = ? WHERE id = ?`
-	_, err := r.db.Exec(query, name, id)
+	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	return nil
+	var oldName string
+	if err := tx.QueryRow(`SELECT name FROM tags WHERE id = ?`, id).Scan(&oldName); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrTagNotFound
+		}
+		return err
+	}
+
+	parentID := 0
+	if i := strings.LastIndex(name, tag.Separator); i > 0 {
+		parent, err := getOrCreateTag(tx, name[:i])
+		if err != nil {
+			return err
+		}
+		parentID = parent.ID
+	}
+
+	query := `UPDATE tags SET name = ?, parent_id = NULLIF(?, 0) WHERE id = ?`
+	if _, err := tx.Exec(query, name, parentID, id); err != nil {
+		return err
+	}
+
+	query = `UPDATE tags SET name = ? || substr(name, ?) WHERE id IN (` + subtreeQuery + `)`
+	start := utf8.RuneCountInString(oldName) + len(tag.Separator) + 1
+	if _, err := tx.Exec(query, name+tag.Separator, start, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
+// GetAll returns every tag with the number of notes (outside the trash) using
+// it directly and the number using it or any of its descendants.
 func (r *tagRepository) GetAll() ([]*tag.Tag, error) {
-	query := `SELECT id, name FROM tags ORDER BY name`
+	query := `
+		WITH RECURSIVE closure(ancestor, descendant) AS (
+			SELECT id, id FROM tags
+			UNION ALL
+			SELECT c.ancestor, t.id FROM closure c JOIN tags t ON t.parent_id = c.descendant
+		)
+		SELECT t.id, t.name, COALESCE(t.parent_id, 0),
+			(SELECT COUNT(n.id)
+				FROM notes_tags nt
+				JOIN notes n ON n.id = nt.note_id AND n.deleted_at IS NULL
+				WHERE nt.tag_id = t.id),
+			(SELECT COUNT(DISTINCT n.id)
+				FROM closure c
+				JOIN notes_tags nt ON nt.tag_id = c.descendant
+				JOIN notes n ON n.id = nt.note_id AND n.deleted_at IS NULL
+				WHERE c.ancestor = t.id)
+		FROM tags t
+		ORDER BY t.name
+	`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -86,7 +169,7 @@ func (r *tagRepository) GetAll() ([]*tag.Tag, error) {
 	var tags []*tag.Tag
 	for rows.Next() {
 		tag := &tag.Tag{}
-		err := rows.Scan(&tag.ID, &tag.Name)
+		err := rows.Scan(&tag.ID, &tag.Name, &tag.ParentID, &tag.NoteCount, &tag.SubtreeCount)
 		if err != nil {
 			return nil, err
 		}
@@ -96,25 +179,102 @@ func (r *tagRepository) GetAll() ([]*tag.Tag, error) {
 	return tags, nil
 }
 
+// Delete removes a tag together with all of its descendants.
 func (r *tagRepository) Delete(id int) error {
-	query := `DELETE FROM tags WHERE id = ?`
-	_, err := r.db.Exec(query, id)
+	query := `DELETE FROM tags WHERE id = ? OR id IN (` + subtreeQuery + `)`
+	_, err := r.db.Exec(query, id, id)
 	return err
 }
 
+// GetOrCreate returns the tag for a path such as "work/infra/k8s", creating it
+// and any missing ancestors.
 func (r *tagRepository) GetOrCreate(name string) (*tag.Tag, error) {
-	retrievedTag, err := r.GetByName(name)
-	if err == nil {
-		return retrievedTag, nil
+	return getOrCreateTag(r.db, name)
+}
+
+func getOrCreateTag(db execQuerier, name string) (*tag.Tag, error) {
+	path := tag.NormalizePath(name)
+	if path == "" {
+		return nil, fmt.Errorf("invalid tag name %q", name)
 	}
 
-	if errors.Is(err, ErrTagNotFound) {
-		newTag := tag.NewTag(name)
-		if err := r.Create(newTag); err != nil {
+	var current *tag.Tag
+	levels := strings.Split(path, tag.Separator)
+	for i := range levels {
+		prefix := strings.Join(levels[:i+1], tag.Separator)
+
+		retrievedTag, err := getTagByName(db, prefix)
+		if err == nil {
+			current = retrievedTag
+			continue
+		}
+		if !errors.Is(err, ErrTagNotFound) {
+			return nil, err
+		}
+
+		newTag := tag.NewTag(prefix)
+		if current != nil {
+			newTag.ParentID = current.ID
+		}
+		if err := createTag(db, newTag); err != nil {
 			return nil, fmt.Errorf("failed to create tag: %w", err)
 		}
-		return newTag, nil
+		current = newTag
+	}
+
+	return current, nil
+}
+
+// Merge moves every note of the source tags to the target tag and deletes the
+// source tags.
+func (r *tagRepository) Merge(sourceIDs []int, targetID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			continue
+		}
+
+		query := `
+			INSERT OR IGNORE INTO notes_tags (note_id, tag_id)
+			SELECT note_id, ? FROM notes_tags WHERE tag_id = ?
+		`
+		if _, err := tx.Exec(query, targetID, sourceID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`DELETE FROM tags WHERE id = ?`, sourceID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Prune deletes tags that neither they nor any of their descendants are
+// attached to a note, including notes in the trash, and returns how many
+// were removed.
+func (r *tagRepository) Prune() (int, error) {
+	query := `
+		WITH RECURSIVE closure(ancestor, descendant) AS (
+			SELECT id, id FROM tags
+			UNION ALL
+			SELECT c.ancestor, t.id FROM closure c JOIN tags t ON t.parent_id = c.descendant
+		)
+		DELETE FROM tags WHERE id NOT IN (
+			SELECT c.ancestor FROM closure c JOIN notes_tags nt ON nt.tag_id = c.descendant
+		)
+	`
+
+	result, err := r.db.Exec(query)
+	if err != nil {
+		return 0, err
 	}
 
-	return nil, err
+	affected, err := result.RowsAffected()
+	return int(affected), err
 }
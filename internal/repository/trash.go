@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+var ErrNotInTrash = errors.New("not found in trash")
+
+func (r *repository) GetDeleted() ([]*note.NoteWithTags, error) {
+	query := `
+		SELECT n.id, n.title, COALESCE(n.slug, ''), n.content, n.created_at, n.updated_at, n.deleted_at, GROUP_CONCAT(t.name) AS tags
+		FROM notes n
+		LEFT JOIN notes_tags nt ON n.id = nt.note_id
+		LEFT JOIN tags t ON nt.tag_id = t.id
+		WHERE n.deleted_at IS NOT NULL
+		GROUP BY n.id
+		ORDER BY n.deleted_at DESC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []*note.NoteWithTags
+	for rows.Next() {
+		note := &note.NoteWithTags{}
+		var deletedAt time.Time
+		var tagsStr sql.NullString
+		err := rows.Scan(&note.ID, &note.Title, &note.Slug, &note.Content, &note.CreatedAt, &note.UpdatedAt, &deletedAt, &tagsStr)
+		if err != nil {
+			return nil, err
+		}
+
+		note.DeletedAt = &deletedAt
+		note.Tags = []string{}
+
+		if tagsStr.Valid && tagsStr.String != "" {
+			note.Tags = strings.Split(tagsStr.String, ",")
+		}
+
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
+
+func (r *repository) Restore(id int) error {
+	query := `UPDATE notes SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotInTrash
+	}
+
+	return nil
+}
+
+// Purge permanently removes notes from the trash. With a nil before every
+// trashed note goes, otherwise only the ones deleted before that time.
+func (r *repository) Purge(before *time.Time) (int, error) {
+	query := `DELETE FROM notes WHERE deleted_at IS NOT NULL`
+	var args []any
+
+	if before != nil {
+		query += ` AND deleted_at < ?`
+		args = append(args, *before)
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
@@ -0,0 +1,15 @@
+package revision
+
+import "time"
+
+// Revision is a snapshot of a note after one of its changes. Revisions are
+// numbered per note starting at 1, the highest number is the current state.
+type Revision struct {
+	ID        int       `json:"id"`
+	NoteID    int       `json:"note_id"`
+	Number    int       `json:"revision"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// ParseSince turns a date ('2025-01-01') or a duration ('30d', '2w', '6m',
+// '1y') into the point in time it refers to. Dates are midnight UTC, as export
+// --since has always read them.
+func ParseSince(since string) (time.Time, error) {
+	if t, err := time.Parse(dateLayout, since); err == nil {
+		return t, nil
+	}
+
+	duration, err := parseDuration(since)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Now().Add(-duration), nil
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid format: %s (use '2025-01-01' or '30d')", value)
+	}
+
+	unit := value[len(value)-1:]
+	valueStr := value[:len(value)-1]
+
+	var n int
+	if _, err := fmt.Sscanf(valueStr, "%d", &n); err != nil {
+		return 0, fmt.Errorf("invalid number in duration: %s", value)
+	}
+
+	switch unit {
+	case "d":
+		return time.Duration(n) * 24 * time.Hour, nil
+	case "w":
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	case "m":
+		return time.Duration(n) * 30 * 24 * time.Hour, nil
+	case "y":
+		return time.Duration(n) * 365 * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid duration unit: %s (use d, w, m, or y)", unit)
+	}
+}
+
+// dateRange resolves the value of a `created:` or `updated:` term into the
+// half-open range [from, to) it selects.
+//
+// Dates compare on the calendar day: `>2025-01-01` starts on the 2nd and
+// `2025-01-01` alone is that whole day. Durations compare on age: `<7d` is
+// anything newer than a week, `>7d` anything older, and no operator means `<`.
+func dateRange(op, value string) (*time.Time, *time.Time, error) {
+	if day, err := time.ParseInLocation(dateLayout, value, time.Local); err == nil {
+		next := day.AddDate(0, 0, 1)
+		switch op {
+		case ">":
+			return &next, nil, nil
+		case ">=":
+			return &day, nil, nil
+		case "<":
+			return nil, &day, nil
+		case "<=":
+			return nil, &next, nil
+		default:
+			return &day, &next, nil
+		}
+	}
+
+	duration, err := parseDuration(value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cutoff := time.Now().Add(-duration)
+	switch op {
+	case ">", ">=":
+		return nil, &cutoff, nil
+	default:
+		return &cutoff, nil, nil
+	}
+}
@@ -0,0 +1,230 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parse reads a search query such as
+//
+//	tag:work -tag:old title:deploy created:>2025-01-01 updated:<7d "exact phrase" OR rollback
+//
+// Terms separated by spaces must all match, OR matches either of its neighbours,
+// a leading `-` negates a term and a trailing `*` matches a word prefix.
+func Parse(input string) (*Query, error) {
+	p := &parser{input: input}
+	q := &Query{Raw: input}
+
+	afterOr := false
+	orPos := 0
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+
+		if p.atOr() {
+			if len(q.Groups) == 0 || afterOr {
+				return nil, p.errorAt(p.pos, "OR needs a term on both sides")
+			}
+			orPos = p.pos
+			p.pos += len("OR")
+			afterOr = true
+			continue
+		}
+
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		if afterOr {
+			last := &q.Groups[len(q.Groups)-1]
+			last.Terms = append(last.Terms, term)
+			afterOr = false
+		} else {
+			q.Groups = append(q.Groups, Group{Terms: []Term{term}})
+		}
+	}
+
+	if afterOr {
+		return nil, p.errorAt(orPos, "OR needs a term on both sides")
+	}
+
+	return q, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	r, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+	return r
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) atOr() bool {
+	if !strings.HasPrefix(p.input[p.pos:], "OR") {
+		return false
+	}
+	end := p.pos + len("OR")
+	if end == len(p.input) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(p.input[end:])
+	return unicode.IsSpace(r)
+}
+
+func (p *parser) errorAt(pos int, format string, args ...any) error {
+	return &SyntaxError{Query: p.input, Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// word reads up to the next space or quote.
+func (p *parser) word() string {
+	start := p.pos
+	for !p.eof() {
+		r := p.peek()
+		if unicode.IsSpace(r) || r == '"' {
+			break
+		}
+		p.pos += utf8.RuneLen(r)
+	}
+	return p.input[start:p.pos]
+}
+
+// quoted reads a "double quoted" string. A quote inside it is written as "".
+func (p *parser) quoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var b strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		p.pos += utf8.RuneLen(r)
+
+		if r != '"' {
+			b.WriteRune(r)
+			continue
+		}
+
+		if !p.eof() && p.peek() == '"' {
+			b.WriteRune('"')
+			p.pos++
+			continue
+		}
+
+		return b.String(), nil
+	}
+
+	return "", p.errorAt(start, "unterminated quote")
+}
+
+func (p *parser) parseTerm() (Term, error) {
+	term := Term{Pos: p.pos}
+
+	if p.peek() == '-' {
+		term.Negated = true
+		p.pos++
+		if p.eof() || unicode.IsSpace(p.peek()) {
+			return term, p.errorAt(term.Pos, "'-' must be followed by a term")
+		}
+	}
+
+	if p.peek() == '"' {
+		value, err := p.quoted()
+		if err != nil {
+			return term, err
+		}
+		if strings.TrimSpace(value) == "" {
+			return term, p.errorAt(term.Pos, "empty phrase")
+		}
+		term.Kind = KindPhrase
+		term.Value = value
+		return term, nil
+	}
+
+	wordPos := p.pos
+	word := p.word()
+
+	field, value, isField := strings.Cut(word, ":")
+	if !isField || field == "" {
+		return p.textTerm(term, word, wordPos)
+	}
+
+	kind, ok := fieldKinds[strings.ToLower(field)]
+	if !ok {
+		return term, p.errorAt(wordPos, "unknown field %q (use title, content, tag, created or updated)", field)
+	}
+	term.Kind = kind
+
+	valuePos := wordPos + len(field) + 1
+	if value == "" && !p.eof() && p.peek() == '"' {
+		quoted, err := p.quoted()
+		if err != nil {
+			return term, err
+		}
+		value = quoted
+	}
+	if strings.TrimSpace(value) == "" {
+		return term, p.errorAt(valuePos, "missing value for %s:", field)
+	}
+
+	switch kind {
+	case KindCreated, KindUpdated:
+		op, date := splitOperator(value)
+		from, to, err := dateRange(op, date)
+		if err != nil {
+			return term, p.errorAt(valuePos+len(op), "%v", err)
+		}
+		term.Value = value
+		term.From, term.To = from, to
+	case KindTitle, KindContent:
+		term.Value = value
+		if strings.HasSuffix(value, "*") {
+			term.Value = strings.TrimSuffix(value, "*")
+			term.Prefix = true
+		}
+	default:
+		term.Value = value
+	}
+
+	return term, nil
+}
+
+func (p *parser) textTerm(term Term, word string, pos int) (Term, error) {
+	term.Kind = KindText
+	term.Value = word
+
+	if strings.HasSuffix(word, "*") {
+		term.Value = strings.TrimSuffix(word, "*")
+		term.Prefix = true
+		if term.Value == "" {
+			return term, p.errorAt(pos, "'*' must follow a word")
+		}
+	}
+
+	return term, nil
+}
+
+func splitOperator(value string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(value, op) {
+			return op, value[len(op):]
+		}
+	}
+	return "", value
+}
@@ -0,0 +1,93 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type TermKind int
+
+const (
+	// KindText matches a word anywhere in the title or content.
+	KindText TermKind = iota
+	// KindPhrase matches an exact, quoted phrase in the title or content.
+	KindPhrase
+	KindTitle
+	KindContent
+	KindTag
+	KindCreated
+	KindUpdated
+)
+
+var fieldKinds = map[string]TermKind{
+	"title":   KindTitle,
+	"content": KindContent,
+	"tag":     KindTag,
+	"created": KindCreated,
+	"updated": KindUpdated,
+}
+
+// Term is a single condition of a query such as `deploy`, `"exact phrase"`,
+// `-tag:old` or `created:>2025-01-01`.
+type Term struct {
+	Kind    TermKind
+	Negated bool
+	Value   string
+	// Prefix is set for words ending in `*` (`deploy*`).
+	Prefix bool
+	// From and To bound date terms to the range [From, To). Either may be nil.
+	From *time.Time
+	To   *time.Time
+	// Pos is the byte offset of the term in the original query.
+	Pos int
+}
+
+// Group holds terms joined with OR.
+type Group struct {
+	Terms []Term
+}
+
+// Query is a parsed search. Every group must match for a note to be returned.
+type Query struct {
+	Raw    string
+	Groups []Group
+}
+
+func (t Term) IsText() bool {
+	switch t.Kind {
+	case KindText, KindPhrase, KindTitle, KindContent:
+		return true
+	}
+	return false
+}
+
+func (t Term) IsDate() bool {
+	return t.Kind == KindCreated || t.Kind == KindUpdated
+}
+
+// Terms returns every term of the query in order.
+func (q *Query) Terms() []Term {
+	var terms []Term
+	for _, g := range q.Groups {
+		terms = append(terms, g.Terms...)
+	}
+	return terms
+}
+
+func (q *Query) IsEmpty() bool {
+	return len(q.Groups) == 0
+}
+
+// SyntaxError points at the token of the query that could not be parsed.
+type SyntaxError struct {
+	Query   string
+	Pos     int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	column := utf8.RuneCountInString(e.Query[:e.Pos])
+	return fmt.Sprintf("invalid query: %s\n  %s\n  %s^", e.Message, e.Query, strings.Repeat(" ", column))
+}
@@ -1,13 +1,39 @@
 package tag
 
+import "strings"
+
+// Separator splits a tag path such as "work/infra/k8s" into its levels.
+const Separator = "/"
 
 type Tag struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentID int    `json:"parent_id,omitempty"`
+	// NoteCount counts notes carrying this exact tag, SubtreeCount also counts
+	// the notes of every descendant tag.
+	NoteCount    int `json:"note_count"`
+	SubtreeCount int `json:"subtree_count"`
 }
 
 func NewTag(name string) *Tag {
 	return &Tag{
-		Name:      name,
+		Name: name,
+	}
+}
+
+// NormalizePath trims spaces and separators around every level of a tag path
+// and drops empty levels, so " work//infra/ " becomes "work/infra".
+func NormalizePath(path string) string {
+	var levels []string
+	for level := range strings.SplitSeq(path, Separator) {
+		if level = strings.TrimSpace(level); level != "" {
+			levels = append(levels, level)
+		}
 	}
+	return strings.Join(levels, Separator)
+}
+
+// Leaf returns the last level of a tag path.
+func (t *Tag) Leaf() string {
+	return t.Name[strings.LastIndex(t.Name, Separator)+1:]
 }
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/handler"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	file := "# team settings\nrows_limit: 8\nline_limit: 40\neditors: [micro, nano]\n"
+	if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Setenv("SNIP_LINE_LIMIT", "50")
+	t.Setenv("SNIP_EDITOR", "")
+	t.Setenv("EDITOR", "")
+
+	cfg, err := config.Load(path, []string{"line_limit=70"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		value  string
+		source config.Source
+	}{
+		{key: "date_format", value: "2006-01-02 15:04:05", source: config.SourceDefault},
+		{key: "rows_limit", value: "8", source: config.SourceFile},
+		{key: "editors", value: "micro,nano", source: config.SourceFile},
+		{key: "line_limit", value: "70", source: config.SourceFlag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			value, err := cfg.Get(tt.key)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if value != tt.value || cfg.Source(tt.key) != tt.source {
+				t.Errorf("Expected %s=%s from %s, got %s from %s", tt.key, tt.value, tt.source, value, cfg.Source(tt.key))
+			}
+		})
+	}
+
+	t.Run("env beats file", func(t *testing.T) {
+		cfg, _ := config.Load(path, nil)
+		if cfg.LineLimit != 50 || cfg.Source("line_limit") != config.SourceEnv {
+			t.Errorf("Expected line_limit 50 from env, got %d from %s", cfg.LineLimit, cfg.Source("line_limit"))
+		}
+	})
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		overrides []string
+		errorMsg  string
+	}{
+		{name: "unknown key in file", file: "colour: red\n", errorMsg: "unknown config key 'colour'"},
+		{name: "bad number in file", file: "rows_limit: many\n", errorMsg: "invalid value for rows_limit"},
+		{name: "malformed yaml", file: "rows_limit: [\n", errorMsg: "failed to parse config file"},
+		{name: "override without value", overrides: []string{"rows_limit"}, errorMsg: "expected key=value"},
+		{name: "negative history limit", overrides: []string{"history_limit=-1"}, errorMsg: "must be at least 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if tt.file != "" {
+				os.WriteFile(path, []byte(tt.file), 0644)
+			}
+
+			_, err := config.Load(path, tt.overrides)
+			if err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			if !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error message to contain '%s', got '%s'", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("# shared by the team\nrows_limit: 8\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	h := handler.NewConfigHandler(path, config.Default(), nil)
+
+	if err := h.SetConfig("rows_limit", "12"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := h.SetConfig("date_format", "02/01/2006"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := h.SetConfig("line_limit", "wide"); err == nil {
+		t.Errorf("Expected invalid value to be rejected")
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# shared by the team") {
+		t.Errorf("Expected comments to be kept, got:\n%s", data)
+	}
+
+	cfg, err := config.Load(path, nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if cfg.RowsLimit != 12 || cfg.DateFormat != "02/01/2006" {
+		t.Errorf("Expected saved settings, got rows_limit=%d date_format=%s", cfg.RowsLimit, cfg.DateFormat)
+	}
+}
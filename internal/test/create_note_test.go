@@ -83,7 +83,7 @@ func TestCreateNote(t *testing.T) {
 			h, mockNoteRepo, mockTagRepo := createTestHandler()
 			tt.setupMocks(mockNoteRepo, mockTagRepo)
 
-			err := h.CreateNote(tt.title, tt.message, tt.tag)
+			err := h.CreateNote(tt.title, tt.message, tt.tag, "")
 
 			if tt.expectError {
 				if err == nil {
@@ -122,7 +122,7 @@ func TestCreateNote_EdgeCases(t *testing.T) {
 		longTitle := "This is a very long title that might cause issues in some systems but should still be valid for our note creation"
 		message := "Test content"
 
-		err := h.CreateNote(longTitle, &message, nil)
+		err := h.CreateNote(longTitle, &message, nil, "")
 
 		if err != nil {
 			t.Errorf("Expected no error for long title, got: %v", err)
@@ -141,7 +141,7 @@ func TestCreateNote_EdgeCases(t *testing.T) {
 		specialTitle := "Note with special chars: @#$%^&*()_+-=[]{}|;':\",./<>?"
 		message := "Test content"
 
-		err := h.CreateNote(specialTitle, &message, nil)
+		err := h.CreateNote(specialTitle, &message, nil, "")
 
 		if err != nil {
 			t.Errorf("Expected no error for special characters, got: %v", err)
@@ -160,7 +160,7 @@ func BenchmarkCreateNote(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := h.CreateNote(title, &message, nil)
+		err := h.CreateNote(title, &message, nil, "")
 		if err != nil {
 			b.Fatalf("CreateNote failed: %v", err)
 		}
@@ -28,7 +28,7 @@ func TestDeleteNote(t *testing.T) {
 			idStr:       "invalid",
 			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
 			expectError: true,
-			errorMsg:    "invalid note ID",
+			errorMsg:    "no note matches",
 		},
 		{
 			name:  "note not found",
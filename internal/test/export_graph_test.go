@@ -0,0 +1,148 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/graph"
+	"github.com/matheuzgomes/Snip/internal/link"
+	"github.com/matheuzgomes/Snip/internal/tag"
+)
+
+func TestBuildGraph(t *testing.T) {
+	notes := createTestNotes()
+	notes[0].Tags = []string{"work/infra"}
+	notes[1].Tags = []string{}
+	notes = notes[:2]
+
+	tags := []*tag.Tag{
+		{ID: 1, Name: "work"},
+		{ID: 2, Name: "work/infra", ParentID: 1},
+		{ID: 3, Name: "unused"},
+	}
+	links := []*link.Link{
+		{SourceID: 2, Ref: "First Note", TargetID: 1},
+		{SourceID: 2, Ref: "#1", TargetID: 1},
+		{SourceID: 2, Ref: "Missing"},
+		{SourceID: 1, Ref: "Third Note", TargetID: 3},
+	}
+
+	g := graph.Build(notes, tags, links)
+
+	if len(g.Nodes) != 4 {
+		t.Errorf("Expected 2 notes and 2 tags, got %+v", g.Nodes)
+	}
+
+	expected := []graph.Edge{
+		{Source: "note:1", Target: "tag:2", Type: graph.EdgeTag},
+		{Source: "tag:2", Target: "tag:1", Type: graph.EdgeParent},
+		{Source: "note:2", Target: "note:1", Type: graph.EdgeLink},
+	}
+	if len(g.Edges) != len(expected) {
+		t.Fatalf("Expected %d edges, got %+v", len(expected), g.Edges)
+	}
+	for i, e := range expected {
+		if g.Edges[i] != e {
+			t.Errorf("Expected edge %+v, got %+v", e, g.Edges[i])
+		}
+	}
+
+	var dot strings.Builder
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !strings.HasPrefix(dot.String(), "digraph snip {") || !strings.Contains(dot.String(), `"note:2" -> "note:1"`) {
+		t.Errorf("Unexpected DOT output:\n%s", dot.String())
+	}
+}
+
+func TestExportGraph(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		since       string
+		setupMocks  func(*mockNoteRepository, *mockTagRepository)
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "dot",
+			format:      "dot",
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: false,
+		},
+		{
+			name:        "json since duration",
+			format:      "json",
+			since:       "30d",
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: false,
+		},
+		{
+			name:        "unknown format",
+			format:      "svg",
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: true,
+			errorMsg:    "invalid format 'svg'",
+		},
+		{
+			name:        "invalid since",
+			format:      "json",
+			since:       "yesterday",
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: true,
+			errorMsg:    "invalid --since value",
+		},
+		{
+			name:   "database error",
+			format: "dot",
+			setupMocks: func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {
+				noteRepo.err = ErrDatabaseConnection
+			},
+			expectError: true,
+			errorMsg:    "failed to fetch notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mockNoteRepo, mockTagRepo := createTestHandler()
+			mockNoteRepo.notesWithTags = createTestNotes()
+			tt.setupMocks(mockNoteRepo, mockTagRepo)
+
+			output := filepath.Join(t.TempDir(), "graph."+tt.format)
+			err := h.ExportGraph(tt.format, output, "", tt.since)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+					return
+				}
+				if tt.errorMsg != "" && !contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error message to contain '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			data, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatalf("Expected graph file, got: %v", err)
+			}
+			if tt.format == "json" {
+				var g graph.Graph
+				if err := json.Unmarshal(data, &g); err != nil {
+					t.Fatalf("Expected valid JSON, got: %v", err)
+				}
+				if len(g.Nodes) == 0 {
+					t.Errorf("Expected notes in the graph")
+				}
+			}
+		})
+	}
+}
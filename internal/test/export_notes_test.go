@@ -1,9 +1,14 @@
 package test
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/handler"
 	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/render"
 )
 
 func TestExportNotes(t *testing.T) {
@@ -113,6 +118,32 @@ func TestExportNotes(t *testing.T) {
 	}
 }
 
+func TestExportNotesStructured(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	for _, title := range []string{"First", "Second"} {
+		if err := noteRepo.Create(note.NewNote(title, "content")); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+	}
+
+	cfg := config.Default()
+	cfg.ExportDir = t.TempDir()
+
+	var buf bytes.Buffer
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.WithOutput(render.New(render.FormatJSON, &buf)))
+	if err := h.ExportNotes("", "json"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var result handler.Result
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Expected only a JSON result, got %q: %v", buf.String(), err)
+	}
+	if result.Status != "exported" || result.Count == nil || *result.Count != 2 {
+		t.Errorf("Expected 2 exported notes, got %+v", result)
+	}
+}
+
 func TestExportNotes_EdgeCases(t *testing.T) {
 	t.Run("export with future date", func(t *testing.T) {
 		h, mockNoteRepo, _ := createTestHandler()
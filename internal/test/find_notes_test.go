@@ -68,6 +68,16 @@ func TestFindNotes(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "invalid query",
+			term: `deploy "unterminated`,
+			setupMocks: func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {
+				noteRepo.err = nil
+				noteRepo.notesWithTags = createTestNotes()
+			},
+			expectError: true,
+			errorMsg:    "unterminated quote",
+		},
 		{
 			name: "repository error",
 			term: "test",
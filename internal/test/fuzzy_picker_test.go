@@ -0,0 +1,131 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matheuzgomes/Snip/internal/fuzzy"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/tui"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		text      string
+		match     bool
+		positions []int
+	}{
+		{name: "in order", pattern: "fnt", text: "First Note", match: true, positions: []int{0, 6, 8}},
+		{name: "ignores case", pattern: "NOTE", text: "First Note", match: true, positions: []int{6, 7, 8, 9}},
+		{name: "prefers word starts", pattern: "dp", text: "update deploy", match: true, positions: []int{7, 9}},
+		{name: "out of order", pattern: "tf", text: "First", match: false},
+		{name: "missing rune", pattern: "xyz", text: "First Note", match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := fuzzy.Score(tt.pattern, tt.text)
+			if ok != tt.match {
+				t.Fatalf("Expected match %v, got %v", tt.match, ok)
+			}
+			if !ok {
+				return
+			}
+			if len(m.Positions) != len(tt.positions) {
+				t.Fatalf("Expected positions %v, got %v", tt.positions, m.Positions)
+			}
+			for i := range tt.positions {
+				if m.Positions[i] != tt.positions[i] {
+					t.Errorf("Expected positions %v, got %v", tt.positions, m.Positions)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestFuzzyScoreRanking(t *testing.T) {
+	consecutive, _ := fuzzy.Score("note", "Notes from today")
+	scattered, _ := fuzzy.Score("note", "New office telephone")
+	if consecutive.Score <= scattered.Score {
+		t.Errorf("Expected a consecutive match to outrank a scattered one, got %d and %d", consecutive.Score, scattered.Score)
+	}
+
+	boundary, _ := fuzzy.Score("k8s", "infra/k8s")
+	inner, _ := fuzzy.Score("k8s", "kubek8sx")
+	if boundary.Score <= inner.Score {
+		t.Errorf("Expected a word start to outrank a match inside a word, got %d and %d", boundary.Score, inner.Score)
+	}
+}
+
+func TestRankNotes(t *testing.T) {
+	notes := createTestNotes()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []int
+	}{
+		{name: "empty query keeps every note", query: "", expected: []int{1, 2, 3}},
+		{name: "title", query: "third", expected: []int{3}},
+		{name: "tags", query: "meeting", expected: []int{3}},
+		{name: "every word must match", query: "note personal", expected: []int{2}},
+		{name: "no match", query: "zzz", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := fuzzy.RankNotes(notes, tt.query)
+			if len(matches) != len(tt.expected) {
+				t.Fatalf("Expected %d matches, got %d", len(tt.expected), len(matches))
+			}
+			for i, id := range tt.expected {
+				if matches[i].Note.ID != id {
+					t.Errorf("Expected note %d at %d, got %d", id, i, matches[i].Note.ID)
+				}
+			}
+		})
+	}
+
+	// a title match outranks the same word in the content only
+	ranked := fuzzy.RankNotes([]*note.NoteWithTags{
+		{ID: 1, Title: "Groceries", Content: "remember the deploy"},
+		{ID: 2, Title: "Deploy checklist", Content: "steps"},
+	}, "deploy")
+	if len(ranked) != 2 || ranked[0].Note.ID != 2 {
+		t.Errorf("Expected the title match first, got %+v", ranked)
+	}
+}
+
+func TestPicker(t *testing.T) {
+	p := tui.NewPicker(createTestNotes(), "second")
+	p.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+
+	view := p.View()
+	if !strings.Contains(view, "1/3") || !strings.Contains(view, "This is the second note content") {
+		t.Errorf("Expected one match with its preview:\n%s", view)
+	}
+
+	for range "second" {
+		p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	chosen, err := p.Chosen()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if chosen.ID != 2 {
+		t.Errorf("Expected note 2, got %d", chosen.ID)
+	}
+
+	cancelled := tui.NewPicker(createTestNotes(), "")
+	cancelled.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, err := cancelled.Chosen(); err != tui.ErrNoSelection {
+		t.Errorf("Expected ErrNoSelection, got %v", err)
+	}
+}
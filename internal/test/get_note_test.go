@@ -43,7 +43,7 @@ func TestGetNote(t *testing.T) {
 			render: false,
 			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
 			expectError: true,
-			errorMsg:    "invalid note ID",
+			errorMsg:    "no note matches",
 		},
 		{
 			name:    "note not found",
@@ -62,7 +62,7 @@ func TestImportNotes(t *testing.T) {
 			h, mockNoteRepo, mockTagRepo := createTestHandler()
 			tt.setupMocks(mockNoteRepo, mockTagRepo)
 
-			err := h.ImportNotes(tt.importDir)
+			err := h.ImportNotes(tt.importDir, false)
 
 			if tt.expectError {
 				if err == nil {
@@ -86,7 +86,7 @@ func TestImportNotes_EdgeCases(t *testing.T) {
 		h, mockNoteRepo, _ := createTestHandler()
 		mockNoteRepo.err = nil
 
-		err := h.ImportNotes("~/test_import")
+		err := h.ImportNotes("~/test_import", false)
 
 		if err != nil && !contains(err.Error(), "failed to read import directory") {
 			t.Errorf("Expected directory error, got: %v", err)
@@ -97,7 +97,7 @@ func TestImportNotes_EdgeCases(t *testing.T) {
 		h, mockNoteRepo, _ := createTestHandler()
 		mockNoteRepo.err = nil
 
-		err := h.ImportNotes("./test_import")
+		err := h.ImportNotes("./test_import", false)
 
 		// This might fail due to directory not existing, which is expected
 		if err != nil && !contains(err.Error(), "failed to read import directory") {
@@ -109,7 +109,7 @@ func TestImportNotes_EdgeCases(t *testing.T) {
 		h, mockNoteRepo, _ := createTestHandler()
 		mockNoteRepo.err = nil
 
-		err := h.ImportNotes("/tmp/test@#$%")
+		err := h.ImportNotes("/tmp/test@#$%", false)
 
 		// This might fail due to directory not existing, which is expected
 		if err != nil && !contains(err.Error(), "failed to read import directory") {
@@ -125,7 +125,7 @@ func BenchmarkImportNotes(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := h.ImportNotes("/tmp/test_import")
+		err := h.ImportNotes("/tmp/test_import", false)
 		if err != nil && !contains(err.Error(), "failed to read import directory") {
 			b.Fatalf("ImportNotes failed: %v", err)
 		}
@@ -0,0 +1,147 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/render"
+)
+
+func TestOpenJournal(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+
+	cfg := config.Default()
+	cfg.Editor = "/nonexistent"
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.Quiet())
+
+	t.Run("creates the note on first use", func(t *testing.T) {
+		err := h.OpenJournal("2025-03-14")
+		if err == nil || !contains(err.Error(), "failed to open editor") {
+			t.Fatalf("Expected the editor to be opened, got %v", err)
+		}
+
+		journal, err := noteRepo.GetJournal("2025-03-14")
+		if err != nil || journal == nil {
+			t.Fatalf("Expected a journal note, got %v, %v", journal, err)
+		}
+		if journal.Title != "2025-03-14" || journal.Slug != "2025-03-14" {
+			t.Errorf("Expected title and slug '2025-03-14', got '%s' and '%s'", journal.Title, journal.Slug)
+		}
+		if !strings.HasPrefix(journal.Content, "# Friday, March 14, 2025\n") {
+			t.Errorf("Expected content from the journal template, got %q", journal.Content)
+		}
+		if !slices.Contains(journal.Tags, "journal") {
+			t.Errorf("Expected the journal tag, got %v", journal.Tags)
+		}
+	})
+
+	t.Run("reuses the note of the day", func(t *testing.T) {
+		h.OpenJournal("2025-03-14")
+
+		notes, err := noteRepo.GetJournals(10)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(notes) != 1 {
+			t.Errorf("Expected 1 journal note, got %d", len(notes))
+		}
+	})
+
+	t.Run("a date never gets two journal notes", func(t *testing.T) {
+		duplicate := note.NewNote("2025-03-14", "")
+		duplicate.JournalDate = "2025-03-14"
+		if err := noteRepo.Create(duplicate); err == nil {
+			t.Errorf("Expected the unique journal date to reject a second note")
+		}
+	})
+
+	t.Run("date slug taken by another note", func(t *testing.T) {
+		other := note.NewNote("2025-03-11", "not a journal note")
+		if err := noteRepo.Create(other); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+
+		err := h.OpenJournal("2025-03-11")
+		if err == nil || !contains(err.Error(), "already has the slug '2025-03-11'") {
+			t.Errorf("Expected a slug error, got %v", err)
+		}
+		if journal, _ := noteRepo.GetJournal("2025-03-11"); journal != nil {
+			t.Errorf("Expected no journal note with a numbered slug, got %+v", journal)
+		}
+	})
+
+	t.Run("trashed journal note", func(t *testing.T) {
+		h.OpenJournal("2025-03-10")
+		journal, _ := noteRepo.GetJournal("2025-03-10")
+		if err := noteRepo.Delete(journal.ID); err != nil {
+			t.Fatalf("failed to trash note: %v", err)
+		}
+
+		err := h.OpenJournal("2025-03-10")
+		if err == nil || !contains(err.Error(), "is in the trash") {
+			t.Errorf("Expected a trash error, got %v", err)
+		}
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		err := h.OpenJournal("someday")
+		if err == nil || !contains(err.Error(), "invalid journal date") {
+			t.Errorf("Expected an invalid date error, got %v", err)
+		}
+
+		err = h.OpenJournal("2025-02-30")
+		if err == nil || err.Error() != "invalid date '2025-02-30'" {
+			t.Errorf("Expected an invalid date error, got %v", err)
+		}
+	})
+
+	t.Run("custom template", func(t *testing.T) {
+		custom := config.Default()
+		custom.Editor = "/nonexistent"
+		custom.JournalTemplate = `{{.Title}}\n- [ ] review inbox\n`
+		h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(custom), handler.Quiet())
+
+		h.OpenJournal("2025-03-12")
+
+		journal, _ := noteRepo.GetJournal("2025-03-12")
+		if journal == nil || journal.Content != "2025-03-12\n- [ ] review inbox\n" {
+			t.Errorf("Expected content from the custom template, got %+v", journal)
+		}
+	})
+}
+
+func TestShowJournal(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+
+	for _, date := range []string{"2025-03-01", "2025-03-03", "2025-03-02"} {
+		journal := note.NewNote(date, "entry of "+date)
+		journal.JournalDate = date
+		if err := noteRepo.Create(journal); err != nil {
+			t.Fatalf("failed to create journal note: %v", err)
+		}
+	}
+	if err := noteRepo.Create(note.NewNote("Not a journal", "")); err != nil {
+		t.Fatalf("failed to create note: %v", err)
+	}
+
+	var buf bytes.Buffer
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithOutput(render.New(render.FormatJSON, &buf)))
+
+	if err := h.ShowJournal(2); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var notes []note.NoteWithTags
+	if err := json.Unmarshal(buf.Bytes(), &notes); err != nil {
+		t.Fatalf("Expected a JSON list, got %q: %v", buf.String(), err)
+	}
+	if len(notes) != 2 || notes[0].Title != "2025-03-02" || notes[1].Title != "2025-03-03" {
+		t.Errorf("Expected the last two entries oldest first, got %+v", notes)
+	}
+}
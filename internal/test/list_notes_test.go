@@ -88,7 +88,7 @@ func TestListNotes(t *testing.T) {
 			h, mockNoteRepo, mockTagRepo := createTestHandler()
 			tt.setupMocks(mockNoteRepo, mockTagRepo)
 
-			err := h.ListNotes(tt.isAsc, tt.verbose, tt.tag)
+			err := h.ListNotes(tt.isAsc, tt.verbose, tt.tag, false, nil, "")
 
 			if tt.expectError {
 				if err == nil {
@@ -112,7 +112,7 @@ func TestListNotes_EdgeCases(t *testing.T) {
 		h, mockNoteRepo, _ := createTestHandler()
 		mockNoteRepo.err = nil
 
-		err := h.ListNotes(true, false, nil)
+		err := h.ListNotes(true, false, nil, false, nil, "")
 
 		if err != nil {
 			t.Errorf("Expected no error for empty list, got: %v", err)
@@ -124,7 +124,7 @@ func TestListNotes_EdgeCases(t *testing.T) {
 		mockNoteRepo.err = nil
 		mockTagRepo.err = ErrNoteNotFound
 
-		err := h.ListNotes(true, false, stringPtr("nonexistent-tag"))
+		err := h.ListNotes(true, false, stringPtr("nonexistent-tag"), false, nil, "")
 
 		if err == nil {
 			t.Errorf("Expected error for invalid tag, got none")
@@ -139,7 +139,7 @@ func BenchmarkListNotes(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := h.ListNotes(true, false, nil)
+		err := h.ListNotes(true, false, nil, false, nil, "")
 		if err != nil {
 			b.Fatalf("ListNotes failed: %v", err)
 		}
@@ -0,0 +1,193 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/database"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/repository"
+	"github.com/matheuzgomes/Snip/internal/tag"
+)
+
+func TestManageTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		run         func(h handler.Handler) error
+		setupMocks  func(*mockNoteRepository, *mockTagRepository)
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "rename tag",
+			run:         func(h handler.Handler) error { return h.RenameTag("work", "job") },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: false,
+		},
+		{
+			name:        "rename to name with spaces",
+			run:         func(h handler.Handler) error { return h.RenameTag("work", "day job") },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: true,
+			errorMsg:    "invalid tag name",
+		},
+		{
+			name:        "rename missing tag",
+			run:         func(h handler.Handler) error { return h.RenameTag("work", "job") },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) { tagRepo.err = ErrTagNotFound },
+			expectError: true,
+			errorMsg:    "failed to fetch tag 'work'",
+		},
+		{
+			name:        "rename under itself",
+			run:         func(h handler.Handler) error { return h.RenameTag("work", "work/old") },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: true,
+			errorMsg:    "cannot move tag 'work' under itself",
+		},
+		{
+			name:        "show tag tree",
+			run:         func(h handler.Handler) error { return h.ShowTagTree() },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: false,
+		},
+		{
+			name:        "merge tags",
+			run:         func(h handler.Handler) error { return h.MergeTags([]string{"todo", "tasks"}, "work") },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: false,
+		},
+		{
+			name:        "merge without target",
+			run:         func(h handler.Handler) error { return h.MergeTags([]string{"todo"}, "") },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: true,
+			errorMsg:    "a target tag is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mockNoteRepo, mockTagRepo := createTestHandler()
+			tt.setupMocks(mockNoteRepo, mockTagRepo)
+
+			err := tt.run(h)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+					return
+				}
+				if tt.errorMsg != "" && !contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error message to contain '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestTagRepository(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+
+	tagNote := func(n *note.Note, names ...string) {
+		for _, name := range names {
+			tg, err := tagRepo.GetOrCreate(name)
+			if err != nil {
+				t.Fatalf("failed to create tag: %v", err)
+			}
+			noteRepo.AddTagToNote(n.ID, tg.ID)
+		}
+	}
+
+	first := note.NewNote("First", "one")
+	second := note.NewNote("Second", "two")
+	noteRepo.Create(first)
+	noteRepo.Create(second)
+	tagNote(first, "Work", "todo")
+	tagNote(second, "work", "tasks", "unused")
+	noteRepo.RemoveTagFromNote(second.ID)
+	tagNote(second, "work", "tasks")
+
+	t.Run("names are case-insensitive", func(t *testing.T) {
+		upper, _ := tagRepo.GetByName("WORK")
+		lower, _ := tagRepo.GetByName("work")
+		if upper == nil || lower == nil || upper.ID != lower.ID {
+			t.Fatalf("Expected WORK and work to be the same tag")
+		}
+
+		if err := tagRepo.Create(&tag.Tag{Name: "wOrK"}); err == nil {
+			t.Errorf("Expected unique constraint to reject 'wOrK'")
+		}
+	})
+
+	t.Run("merge and prune", func(t *testing.T) {
+		todo, _ := tagRepo.GetByName("todo")
+		tasks, _ := tagRepo.GetByName("tasks")
+		work, _ := tagRepo.GetByName("work")
+
+		if err := tagRepo.Merge([]int{todo.ID, tasks.ID}, work.ID); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		pruned, err := tagRepo.Prune()
+		if err != nil || pruned != 1 {
+			t.Errorf("Expected 'unused' to be pruned, got %d (%v)", pruned, err)
+		}
+
+		tags, _ := tagRepo.GetAll()
+		if len(tags) != 1 || tags[0].NoteCount != 2 {
+			t.Fatalf("Expected only 'Work' with 2 notes, got %+v", tags)
+		}
+	})
+
+	t.Run("delete removes tag from notes", func(t *testing.T) {
+		work, _ := tagRepo.GetByName("work")
+		if err := tagRepo.Delete(work.ID); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		tags, _ := noteRepo.GetTagsByNote(first.ID)
+		if len(tags) != 0 {
+			t.Errorf("Expected note to have no tags left, got %d", len(tags))
+		}
+	})
+}
+
+func TestTagMigration_MergesCaseDuplicates(t *testing.T) {
+	db := openTestDB(t)
+
+	legacy := `
+		CREATE TABLE notes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			content TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE tags (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL);
+		CREATE TABLE notes_tags (note_id INTEGER NOT NULL, tag_id INTEGER NOT NULL, PRIMARY KEY (note_id, tag_id));
+		INSERT INTO notes (title, content) VALUES ('a', 'a'), ('b', 'b');
+		INSERT INTO tags (name) VALUES ('Work'), ('work'), ('');
+		INSERT INTO notes_tags (note_id, tag_id) VALUES (1, 1), (2, 2), (2, 3);
+	`
+	if _, err := db.Exec(legacy); err != nil {
+		t.Fatalf("failed to build legacy schema: %v", err)
+	}
+
+	migrateTestDB(t, db)
+
+	tagRepo, _ := repository.NewTagRepository(db)
+	tags, err := tagRepo.GetAll()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Name != "Work" || tags[0].NoteCount != 2 {
+		t.Errorf("Expected a single 'Work' tag on 2 notes, got %+v", tags)
+	}
+
+	if statuses, _ := database.Status(db); !statuses[len(statuses)-1].Applied {
+		t.Errorf("Expected every migration to be applied")
+	}
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/database"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Run("fresh database", func(t *testing.T) {
+		db := openTestDB(t)
+
+		applied := migrateTestDB(t, db)
+		if len(applied) == 0 {
+			t.Errorf("Expected migrations to be applied on a fresh database")
+		}
+
+		statuses, err := database.Status(db)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		for _, s := range statuses {
+			if !s.Applied {
+				t.Errorf("Expected migration %d to be applied", s.Version)
+			}
+		}
+	})
+
+	t.Run("second run is a no-op", func(t *testing.T) {
+		db := openTestDB(t)
+
+		migrateTestDB(t, db)
+
+		applied, err := database.Migrate(db)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(applied) != 0 {
+			t.Errorf("Expected no migrations on second run, got %d", len(applied))
+		}
+	})
+
+	t.Run("legacy database upgrades in place", func(t *testing.T) {
+		db := openTestDB(t)
+
+		legacy := `
+			CREATE TABLE notes (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				title TEXT NOT NULL,
+				content TEXT NOT NULL,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE TABLE tags (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL);
+			CREATE TABLE notes_tags (note_id INTEGER NOT NULL, tag_id INTEGER NOT NULL, PRIMARY KEY (note_id, tag_id));
+			CREATE VIRTUAL TABLE notes_fts USING fts4(id, title, content);
+			INSERT INTO notes (title, content) VALUES ('Legacy Note', 'kept across upgrades');
+			INSERT INTO notes_fts (id, title, content) VALUES (1, 'Legacy Note', 'kept across upgrades');
+		`
+		if _, err := db.Exec(legacy); err != nil {
+			t.Fatalf("failed to build legacy schema: %v", err)
+		}
+
+		migrateTestDB(t, db)
+
+		var title string
+		if err := db.QueryRow(`SELECT title FROM notes WHERE id = 1`).Scan(&title); err != nil {
+			t.Fatalf("Expected legacy note to survive migration, got: %v", err)
+		}
+		if title != "Legacy Note" {
+			t.Errorf("Expected title 'Legacy Note', got '%s'", title)
+		}
+
+		var slug string
+		if err := db.QueryRow(`SELECT slug FROM notes WHERE id = 1`).Scan(&slug); err != nil {
+			t.Fatalf("Expected legacy note to get a slug, got: %v", err)
+		}
+		if slug != "legacy-note" {
+			t.Errorf("Expected slug 'legacy-note', got '%s'", slug)
+		}
+	})
+}
@@ -0,0 +1,284 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/repository"
+	"github.com/matheuzgomes/Snip/internal/validation"
+)
+
+// scriptedEditor returns a config whose editor replaces the file it opens
+// with the next of buffers, the last one again once they run out. What the
+// editor was shown the last time is kept in the returned file.
+func scriptedEditor(t *testing.T, buffers ...string) (*config.Config, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("scripted editor needs a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	for i, buffer := range buffers {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("buffer-%d.md", i+1)), []byte(buffer), 0644); err != nil {
+			t.Fatalf("failed to write buffer: %v", err)
+		}
+	}
+
+	script := fmt.Sprintf(`#!/bin/sh
+cd %q
+n=$(( $(cat opened 2>/dev/null || echo 0) + 1 ))
+[ $n -gt %d ] && n=%d
+echo $n > opened
+cp "$1" seen.md
+cp buffer-$n.md "$1"
+`, dir, len(buffers), len(buffers))
+
+	editor := filepath.Join(dir, "editor.sh")
+	if err := os.WriteFile(editor, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write editor: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Editor = editor
+	return cfg, filepath.Join(dir, "seen.md")
+}
+
+func createFrontMatterNotes(t *testing.T, noteRepo repository.NoteRepository, tagRepo repository.TagRepository) {
+	t.Helper()
+
+	for _, title := range []string{"Sprint review", "Taken"} {
+		if err := noteRepo.Create(note.NewNote(title, "body of "+title+"\n")); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+	}
+	work, err := tagRepo.GetOrCreate("work")
+	if err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+	if err := noteRepo.AddTagToNote(1, work.ID); err != nil {
+		t.Fatalf("failed to tag note: %v", err)
+	}
+}
+
+func TestUpdateNoteFrontMatter(t *testing.T) {
+	tests := []struct {
+		name        string
+		buffer      string
+		title       string
+		slug        string
+		tags        []string
+		content     string
+		properties  []string
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:    "renames, retags and edits together",
+			buffer:  "---\ntitle: 'Sprint review: week 12'\nslug: sprint-12\ntags: [work/meetings, team, team]\n---\nnew body\n",
+			title:   "Sprint review: week 12",
+			slug:    "sprint-12",
+			tags:    []string{"team", "work/meetings"},
+			content: "new body\n",
+		},
+		{
+			name:    "left out keys are kept",
+			buffer:  "---\ntitle: Renamed\n---\nnew body\n",
+			title:   "Renamed",
+			slug:    "sprint-review",
+			tags:    []string{"work"},
+			content: "new body\n",
+		},
+		{
+			name:    "no front matter edits the content only",
+			buffer:  "just content\n",
+			title:   "Sprint review",
+			slug:    "sprint-review",
+			tags:    []string{"work"},
+			content: "just content\n",
+		},
+		{
+			name:    "empty tags remove every tag",
+			buffer:  "---\ntags: []\n---\nbody\n",
+			title:   "Sprint review",
+			slug:    "sprint-review",
+			content: "body\n",
+		},
+		{
+			name:        "malformed yaml",
+			buffer:      "---\ntags: [a\n---\nbody\n",
+			expectError: true,
+			errorMsg:    "your edit is kept in",
+		},
+		{
+			name:        "unclosed front matter",
+			buffer:      "---\ntitle: x\nbody\n",
+			expectError: true,
+			errorMsg:    "no closing '---' line",
+		},
+		{
+			name:       "other keys are properties",
+			buffer:     "---\nstatus: done\npriority: 2\ndue: 2025-03-14\nowners: [ana, rui]\n---\nbody\n",
+			title:      "Sprint review",
+			slug:       "sprint-review",
+			tags:       []string{"work"},
+			content:    "body\n",
+			properties: []string{"due=2025-03-14 (date)", "owners=[\"ana\",\"rui\"] (list)", "priority=2 (number)", "status=done (string)"},
+		},
+		{
+			name:        "nested property value",
+			buffer:      "---\nstatus: {a: 1}\n---\nbody\n",
+			expectError: true,
+			errorMsg:    "unsupported value for 'status'",
+		},
+		{
+			name:        "invalid property name",
+			buffer:      "---\n'two words': x\n---\nbody\n",
+			expectError: true,
+			errorMsg:    "invalid property name 'two words'",
+		},
+		{
+			name:        "empty title",
+			buffer:      "---\ntitle: ' '\n---\nbody\n",
+			expectError: true,
+			errorMsg:    "title is required",
+		},
+		{
+			name:        "invalid slug",
+			buffer:      "---\nslug: Not A Slug\n---\nbody\n",
+			expectError: true,
+			errorMsg:    "slug 'Not A Slug' is invalid",
+		},
+		{
+			name:        "slug of another note",
+			buffer:      "---\nslug: taken\n---\nbody\n",
+			expectError: true,
+			errorMsg:    "slug is already used",
+		},
+		{
+			name:        "tag with spaces",
+			buffer:      "---\ntags: [two words]\n---\nbody\n",
+			expectError: true,
+			errorMsg:    "cannot contain spaces",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteRepo, tagRepo := newTestRepositories(t)
+			createFrontMatterNotes(t, noteRepo, tagRepo)
+
+			cfg, seen := scriptedEditor(t, tt.buffer)
+			h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.Quiet())
+
+			err := h.UpdateNote("1", "")
+
+			updated, getErr := noteRepo.GetByID(1)
+			if getErr != nil {
+				t.Fatalf("failed to fetch note: %v", getErr)
+			}
+
+			if tt.expectError {
+				if err == nil || !contains(err.Error(), tt.errorMsg) {
+					t.Fatalf("Expected error containing '%s', got %v", tt.errorMsg, err)
+				}
+				if updated.Title != "Sprint review" || updated.Content != "body of Sprint review\n" {
+					t.Errorf("Expected the note to be left alone, got %+v", updated)
+				}
+
+				shown, _ := os.ReadFile(seen)
+				if !strings.Contains(string(shown), "# snip: ") {
+					t.Errorf("Expected the editor to reopen with the error, got %q", shown)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			slices.Sort(updated.Tags)
+			if updated.Title != tt.title || updated.Slug != tt.slug || updated.Content != tt.content || !slices.Equal(updated.Tags, tt.tags) {
+				t.Errorf("Expected %q %q %v %q, got %q %q %v %q",
+					tt.title, tt.slug, tt.tags, tt.content, updated.Title, updated.Slug, updated.Tags, updated.Content)
+			}
+
+			var properties []string
+			for _, p := range updated.Properties {
+				properties = append(properties, fmt.Sprintf("%s=%s (%s)", p.Key, p.Value, p.Type))
+			}
+			if !slices.Equal(properties, tt.properties) {
+				t.Errorf("Expected properties %v, got %v", tt.properties, properties)
+			}
+		})
+	}
+}
+
+func TestUpdateNoteFrontMatterRoundTrip(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	createFrontMatterNotes(t, noteRepo, tagRepo)
+
+	cfg := config.Default()
+	cfg.Editor = "true"
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.Quiet())
+
+	if err := h.UpdateNote("sprint-review", ""); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	updated, err := noteRepo.GetByID(1)
+	if err != nil {
+		t.Fatalf("failed to fetch note: %v", err)
+	}
+	if updated.Title != "Sprint review" || updated.Slug != "sprint-review" || updated.Content != "body of Sprint review\n" || !slices.Equal(updated.Tags, []string{"work"}) {
+		t.Errorf("Expected an unchanged buffer to keep the note as it was, got %+v", updated)
+	}
+}
+
+func TestUpdateNoteCanceled(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	createFrontMatterNotes(t, noteRepo, tagRepo)
+
+	// the first save is invalid, then the file is emptied
+	cfg, _ := scriptedEditor(t, "---\nslug: Bad\n---\nlost\n", "")
+
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.Quiet())
+	if err := h.UpdateNote("1", ""); err != nil {
+		t.Fatalf("Expected a canceled edit to be no error, got: %v", err)
+	}
+
+	updated, _ := noteRepo.GetByID(1)
+	if updated.Content != "body of Sprint review\n" {
+		t.Errorf("Expected the note to be left alone, got %q", updated.Content)
+	}
+}
+
+func TestValidateSlugAndTag(t *testing.T) {
+	v := validation.NewValidator()
+
+	for _, slug := range []string{"sprint-12", "note-42", "café"} {
+		if err := v.ValidateSlug(slug); err != nil {
+			t.Errorf("Expected slug '%s' to be valid, got %v", slug, err)
+		}
+	}
+	for _, slug := range []string{"", "Upper", "two--dashes", "-leading", "with space", "42"} {
+		if err := v.ValidateSlug(slug); err == nil {
+			t.Errorf("Expected slug '%s' to be invalid", slug)
+		}
+	}
+
+	if err := v.ValidateTag("work/infra"); err != nil {
+		t.Errorf("Expected a nested tag to be valid, got %v", err)
+	}
+	for _, name := range []string{"", " / ", "two words"} {
+		if err := v.ValidateTag(name); err == nil {
+			t.Errorf("Expected tag '%s' to be invalid", name)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/diff"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/link"
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+func TestNoteHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		run         func(h handler.Handler) error
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "show history",
+			run:         func(h handler.Handler) error { return h.ShowHistory("1") },
+			expectError: false,
+		},
+		{
+			name:        "show history invalid id",
+			run:         func(h handler.Handler) error { return h.ShowHistory("abc") },
+			expectError: true,
+			errorMsg:    "no note matches",
+		},
+		{
+			name:        "diff latest revisions",
+			run:         func(h handler.Handler) error { return h.DiffNote("1", "", "") },
+			expectError: false,
+		},
+		{
+			name:        "diff unknown revision",
+			run:         func(h handler.Handler) error { return h.DiffNote("1", "r9", "") },
+			expectError: true,
+			errorMsg:    "revision not found",
+		},
+		{
+			name:        "revert to first revision",
+			run:         func(h handler.Handler) error { return h.RevertNote("1", "r1") },
+			expectError: false,
+		},
+		{
+			name:        "revert invalid revision",
+			run:         func(h handler.Handler) error { return h.RevertNote("1", "latest") },
+			expectError: true,
+			errorMsg:    "invalid revision",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mockNoteRepo, _ := createTestHandler()
+			mockNoteRepo.notesWithTags = createTestNotes()
+
+			mockNoteRepo.SaveRevision(1)
+			mockNoteRepo.notesWithTags[0].Content = "This is the edited first note content"
+			mockNoteRepo.SaveRevision(1)
+
+			err := tt.run(h)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+					return
+				}
+				if tt.errorMsg != "" && !contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error message to contain '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestNoteHistory_Revert(t *testing.T) {
+	h, mockNoteRepo, _ := createTestHandler()
+	mockNoteRepo.notesWithTags = createTestNotes()
+
+	original := mockNoteRepo.notesWithTags[0].Content
+	mockNoteRepo.SaveRevision(1)
+	mockNoteRepo.notesWithTags[0].Content = "overwritten by a bad save"
+	mockNoteRepo.SaveRevision(1)
+
+	if err := h.RevertNote("1", "1"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if mockNoteRepo.notesWithTags[0].Content != original {
+		t.Errorf("Expected content '%s', got '%s'", original, mockNoteRepo.notesWithTags[0].Content)
+	}
+
+	revisions, _ := mockNoteRepo.GetRevisions(1)
+	if len(revisions) != 3 || revisions[0].Number != 3 {
+		t.Errorf("Expected revert to be saved as revision 3, got %d revision(s)", len(revisions))
+	}
+}
+
+func TestRevisionRepository(t *testing.T) {
+	noteRepo, _ := newTestRepositories(t)
+
+	n := note.NewNote("Runbook", "step one")
+	if err := noteRepo.Create(n); err != nil {
+		t.Fatalf("failed to create note: %v", err)
+	}
+
+	noteRepo.SaveRevision(n.ID)
+	noteRepo.SaveRevision(n.ID)
+
+	revisions, err := noteRepo.GetRevisions(n.ID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(revisions) != 1 {
+		t.Fatalf("Expected unchanged note to keep 1 revision, got %d", len(revisions))
+	}
+
+	for _, content := range []string{"step two", "step three", "step four"} {
+		noteRepo.Update(n.ID, content, "")
+		if err := noteRepo.SaveRevision(n.ID); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+
+	if err := noteRepo.PruneRevisions(n.ID, 2); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	revisions, _ = noteRepo.GetRevisions(n.ID)
+	if len(revisions) != 2 || revisions[0].Number != 4 || revisions[0].Content != "step four" {
+		t.Errorf("Expected revisions 4 and 3 to remain, got %+v", revisions)
+	}
+}
+
+func TestRevertNoteRepository(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+
+	tags := "ops"
+	if err := h.CreateNote("Runbook", stringPtr("step one"), &tags, ""); err != nil {
+		t.Fatalf("failed to create note: %v", err)
+	}
+	tags = "draft"
+	if err := h.PatchNote("1", nil, &tags, nil, false); err != nil {
+		t.Fatalf("failed to patch note: %v", err)
+	}
+	noteRepo.Update(1, "step two, see [[Runbook]]", "")
+	noteRepo.SaveLinks(1, link.Parse("step two, see [[Runbook]]"))
+
+	if err := h.RevertNote("1", "r1"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	n, _ := noteRepo.GetByID(1)
+	if n.Content != "step one" || strings.Join(n.Tags, ",") != "ops" {
+		t.Errorf("Expected r1 to be restored, got %q %v", n.Content, n.Tags)
+	}
+	revisions, _ := noteRepo.GetRevisions(1)
+	if len(revisions) == 0 || revisions[0].Content != "step one" {
+		t.Errorf("Expected the revert to be recorded, got %+v", revisions)
+	}
+	if links, _ := noteRepo.GetLinks(1); len(links) != 0 {
+		t.Errorf("Expected the links of the restored content, got %+v", links)
+	}
+
+	before := len(revisions)
+	noteRepo.Delete(1)
+	if err := noteRepo.RevertNote(1, revisions[len(revisions)-1], nil, 0); err == nil {
+		t.Fatalf("Expected a trashed note not to be reverted")
+	}
+	if revisions, _ = noteRepo.GetRevisions(1); len(revisions) != before {
+		t.Errorf("Expected a failed revert to record nothing, got %d revision(s)", len(revisions))
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+	b := "one\ntwo\nTHREE\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\n"
+
+	got := diff.Unified("a", "b", a, b, 1)
+	want := strings.Join([]string{
+		"--- a",
+		"+++ b",
+		"@@ -2,3 +2,3 @@",
+		" two",
+		"-three",
+		"+THREE",
+		" four",
+		"@@ -10 +10,2 @@",
+		" ten",
+		"+eleven",
+		"",
+	}, "\n")
+
+	if got != want {
+		t.Errorf("Unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	if diff.Unified("a", "b", a, a, 3) != "" {
+		t.Errorf("Expected no diff for equal input")
+	}
+}
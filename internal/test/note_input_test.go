@@ -0,0 +1,187 @@
+package test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+func TestReadContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		oneByte     bool
+		expectError error
+	}{
+		{name: "plain text", input: "line one\nline two\n"},
+		{name: "empty input", input: ""},
+		{name: "multibyte runes split across reads", input: "café ☕ naïve 🚀", oneByte: true},
+		{name: "large input", input: strings.Repeat("kubectl logs line ✓\n", 20000)},
+		{name: "nul byte", input: "text\x00more", expectError: handler.ErrBinaryInput},
+		{name: "invalid utf8", input: "ok\xff\xfe", expectError: handler.ErrInvalidUTF8},
+		{name: "truncated rune at the end", input: "caf\xc3", oneByte: true, expectError: handler.ErrInvalidUTF8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r io.Reader = strings.NewReader(tt.input)
+			if tt.oneByte {
+				r = iotest.OneByteReader(r)
+			}
+
+			content, err := handler.ReadContent(r)
+
+			if tt.expectError != nil {
+				if !errors.Is(err, tt.expectError) {
+					t.Errorf("Expected error '%v', got '%v'", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if content != tt.input {
+				t.Errorf("Expected content of %d bytes to round trip, got %d bytes", len(tt.input), len(content))
+			}
+		})
+	}
+}
+
+func TestAppendNote(t *testing.T) {
+	tests := []struct {
+		name        string
+		idStr       string
+		noteID      int
+		content     string
+		prepend     bool
+		timestamp   bool
+		expected    string
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:     "appends on a new line",
+			idStr:    "1",
+			noteID:   1,
+			content:  "more log",
+			expected: "This is the first note content\nmore log",
+		},
+		{
+			name:     "by slug",
+			idStr:    "second-note",
+			noteID:   2,
+			content:  "line\n",
+			expected: "This is the second note content\nline\n",
+		},
+		{
+			name:     "prepend",
+			idStr:    "3",
+			noteID:   3,
+			content:  "latest entry",
+			prepend:  true,
+			expected: "latest entry\nThis is the third note content",
+		},
+		{
+			name:      "timestamped entry",
+			idStr:     "1",
+			noteID:    1,
+			content:   "rolled back",
+			timestamp: true,
+			expected:  "This is the first note content\n### " + time.Now().Format("2006-01-02") + " ",
+		},
+		{
+			name:        "empty content",
+			idStr:       "1",
+			content:     " \n",
+			expectError: true,
+			errorMsg:    "nothing to add",
+		},
+		{
+			name:        "note not found",
+			idStr:       "999",
+			content:     "text",
+			expectError: true,
+			errorMsg:    "failed to add to note",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, noteRepo, _ := createTestHandler()
+			noteRepo.notesWithTags = createTestNotes()
+
+			var err error
+			if tt.prepend {
+				err = h.PrependNote(tt.idStr, tt.content, tt.timestamp)
+			} else {
+				err = h.AppendNote(tt.idStr, tt.content, tt.timestamp)
+			}
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				} else if !contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error message to contain '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			updated, _ := noteRepo.GetByID(tt.noteID)
+			if tt.timestamp {
+				if !strings.HasPrefix(updated.Content, tt.expected) || !strings.HasSuffix(updated.Content, "\n"+tt.content) {
+					t.Errorf("Expected a timestamped entry, got %q", updated.Content)
+				}
+			} else if updated.Content != tt.expected {
+				t.Errorf("Expected content %q, got %q", tt.expected, updated.Content)
+			}
+		})
+	}
+}
+
+func TestAppendPrependSQL(t *testing.T) {
+	noteRepo, _ := newTestRepositories(t)
+
+	n := note.NewNote("Incident log", "paged")
+	if err := noteRepo.Create(n); err != nil {
+		t.Fatalf("failed to create note: %v", err)
+	}
+
+	if err := noteRepo.Append(n.ID, "rolled back"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := noteRepo.Prepend(n.ID, "resolved"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	stored, err := noteRepo.GetByID(n.ID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if stored.Content != "resolved\npaged\nrolled back" {
+		t.Errorf("Expected content %q, got %q", "resolved\npaged\nrolled back", stored.Content)
+	}
+	if !stored.UpdatedAt.After(n.UpdatedAt) {
+		t.Errorf("Expected updated_at to move forward")
+	}
+
+	results, err := noteRepo.Search(mustParse(t, "rolled"))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != n.ID {
+		t.Errorf("Expected the appended text to be searchable, got %d result(s)", len(results))
+	}
+
+	if err := noteRepo.Append(999, "text"); err == nil {
+		t.Errorf("Expected an error appending to a missing note")
+	}
+}
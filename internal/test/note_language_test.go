@@ -0,0 +1,244 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/language"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/render"
+)
+
+func TestLanguageNames(t *testing.T) {
+	tests := []struct {
+		name        string
+		normalized  string
+		extension   string
+		expectError bool
+	}{
+		{name: "go", normalized: "go", extension: ".go"},
+		{name: "Golang", normalized: "go", extension: ".go"},
+		{name: "py", normalized: "python", extension: ".py"},
+		{name: "sh", normalized: "bash", extension: ".sh"},
+		{name: "SQL", normalized: "sql", extension: ".sql"},
+		{name: "markdown", normalized: "", extension: ".txt"},
+		{name: "none", normalized: "", extension: ".txt"},
+		{name: "klingon", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := language.Normalize(tt.name)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if normalized != tt.normalized || language.Extension(normalized) != tt.extension {
+				t.Errorf("Expected %q %q, got %q %q", tt.normalized, tt.extension, normalized, language.Extension(normalized))
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "fenced block", content: "```go\nfunc main() {}\n```\n", expected: "go"},
+		{name: "shebang", content: "#!/bin/bash\nset -e\n", expected: "bash"},
+		{name: "env shebang", content: "#!/usr/bin/env python3\nprint(1)\n", expected: "python"},
+		{name: "prose", content: "the package arrives on monday", expected: ""},
+		{name: "markdown with a block", content: "Run this:\n\n```sql\nSELECT 1;\n```\n", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := language.Detect(tt.content); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	for filename, expected := range map[string]string{"top.sql": "sql", "deploy.sh": "bash", "main.go": "go", "notes.md": "", "notes.txt": ""} {
+		if got := language.FromFilename(filename); got != expected {
+			t.Errorf("Expected %s to be %q, got %q", filename, expected, got)
+		}
+	}
+}
+
+func TestCreateAndListByLanguage(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+
+	snippets := []struct {
+		title   string
+		content string
+		lang    string
+	}{
+		{title: "Retry", content: "for i := 0; i < 3; i++ {}", lang: "golang"},
+		{title: "Deploy", content: "#!/bin/sh\nmake deploy\n", lang: language.Auto},
+		{title: "Groceries", content: "milk, eggs", lang: ""},
+	}
+	for _, s := range snippets {
+		if err := h.CreateNote(s.title, stringPtr(s.content), nil, s.lang); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+	if err := h.CreateNote("Bad", stringPtr("x"), nil, "klingon"); err == nil || !contains(err.Error(), "unknown language") {
+		t.Errorf("Expected an unknown language error, got %v", err)
+	}
+
+	for id, expected := range map[int]string{1: "go", 2: "bash", 3: ""} {
+		n, err := noteRepo.GetByID(id)
+		if err != nil {
+			t.Fatalf("failed to fetch note: %v", err)
+		}
+		if n.Language != expected {
+			t.Errorf("Expected note %d to be %q, got %q", id, expected, n.Language)
+		}
+	}
+
+	var buf bytes.Buffer
+	structured := handler.NewHandler(noteRepo, tagRepo, handler.WithOutput(render.New(render.FormatJSON, &buf)))
+	if err := structured.ListNotes(false, false, nil, false, nil, "sh"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var notes []note.NoteWithTags
+	if err := json.Unmarshal(buf.Bytes(), &notes); err != nil {
+		t.Fatalf("Expected JSON list, got %q: %v", buf.String(), err)
+	}
+	if len(notes) != 1 || notes[0].Title != "Deploy" || notes[0].Language != "bash" {
+		t.Errorf("Expected only the bash snippet, got %+v", notes)
+	}
+}
+
+func TestPatchNoteLanguage(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+
+	if err := h.CreateNote("Query", stringPtr("SELECT 1;"), nil, ""); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	for _, tt := range []struct {
+		lang     string
+		expected string
+	}{
+		{lang: "sql", expected: "sql"},
+		{lang: "none", expected: ""},
+	} {
+		if err := h.PatchNote("1", nil, nil, stringPtr(tt.lang), false); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		n, _ := noteRepo.GetByID(1)
+		if n.Language != tt.expected {
+			t.Errorf("Expected %q after --lang %s, got %q", tt.expected, tt.lang, n.Language)
+		}
+	}
+
+	if err := h.PatchNote("1", nil, nil, stringPtr("klingon"), false); err == nil {
+		t.Errorf("Expected an unknown language to be rejected")
+	}
+}
+
+func TestUpdateNoteLanguageFrontMatter(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	createFrontMatterNotes(t, noteRepo, tagRepo)
+
+	cfg, _ := scriptedEditor(t, "---\nlanguage: golang\n---\npackage main\n")
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.Quiet())
+	if err := h.UpdateNote("1", ""); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	n, _ := noteRepo.GetByID(1)
+	if n.Language != "go" || n.Content != "package main\n" {
+		t.Errorf("Expected a go snippet, got %q %q", n.Language, n.Content)
+	}
+}
+
+func TestSnippetExportImport(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+	if err := h.CreateNote("Top queries", stringPtr("SELECT 1;\n"), nil, "sql"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := h.CreateNote("deploy.sh", stringPtr("make deploy\n"), nil, "bash"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := h.CreateNote("Groceries", stringPtr("milk\n"), nil, ""); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	exportDir := filepath.Join(home, "snippets")
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
+		t.Fatalf("failed to create export dir: %v", err)
+	}
+	if _, err := noteRepo.ExportNotes(exportDir, nil, "raw"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	for _, name := range []string{"1_Top_queries.sql", "2_deploy.sh", "3_Groceries.txt"} {
+		if _, err := os.Stat(filepath.Join(exportDir, name)); err != nil {
+			t.Errorf("Expected %s to be exported: %v", name, err)
+		}
+	}
+
+	extra := map[string]string{
+		".bashrc":  "export PATH=$HOME/bin:$PATH\n",
+		"retry.md": "```go\nfor i := 0; i < 3; i++ {}\n```\n",
+	}
+	for name, content := range extra {
+		if err := os.WriteFile(filepath.Join(exportDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	notesRepo, notesTagRepo := newTestRepositories(t)
+	notesOnly := handler.NewHandler(notesRepo, notesTagRepo, handler.Quiet())
+	if err := notesOnly.ImportNotes("snippets", false); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	notes, _ := notesRepo.GetAll(false, 0, false)
+	if len(notes) != 1 || notes[0].Title != "retry" || notes[0].Language != "go" {
+		t.Errorf("Expected only the markdown note, in go, without --snippets, got %+v", notes)
+	}
+
+	importRepo, importTagRepo := newTestRepositories(t)
+	imported := handler.NewHandler(importRepo, importTagRepo, handler.Quiet())
+	if err := imported.ImportNotes("snippets", true); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	n, err := importRepo.GetByID(1)
+	if err != nil {
+		t.Fatalf("failed to fetch imported note: %v", err)
+	}
+	if n.Title != "1_Top_queries.sql" || n.Language != "sql" || n.Content != "SELECT 1;\n" {
+		t.Errorf("Expected a sql snippet named after its file, got %+v", n)
+	}
+
+	all, _ := importRepo.GetAll(false, 0, false)
+	for _, n := range all {
+		if n.Title == ".bashrc" {
+			t.Errorf("Expected dotfiles to be skipped")
+		}
+	}
+	if len(all) != 3 {
+		t.Errorf("Expected the two snippets and the markdown note, got %d note(s)", len(all))
+	}
+}
@@ -0,0 +1,219 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/link"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/repository"
+)
+
+func TestParseLinks(t *testing.T) {
+	refs := link.Parse("See [[Deploy Guide]], [[#42]] and [[ deploy guide ]]. Not [[]] nor [[#x]].")
+
+	if len(refs) != 3 {
+		t.Fatalf("Expected 3 distinct references, got %+v", refs)
+	}
+	if refs[0].Text != "Deploy Guide" || refs[0].NoteID != 0 {
+		t.Errorf("Expected title reference 'Deploy Guide', got %+v", refs[0])
+	}
+	if refs[1].Text != "#42" || refs[1].NoteID != 42 {
+		t.Errorf("Expected id reference #42, got %+v", refs[1])
+	}
+	if refs[2].Text != "#x" || refs[2].NoteID != 0 {
+		t.Errorf("Expected '#x' to be kept as a title reference, got %+v", refs[2])
+	}
+}
+
+func TestRewriteTitle(t *testing.T) {
+	content, count := link.RewriteTitle("[[Old]] and [[ old ]] but not [[Older]] or Old", "Old", "New")
+
+	if count != 2 {
+		t.Errorf("Expected 2 replacements, got %d", count)
+	}
+	if content != "[[New]] and [[New]] but not [[Older]] or Old" {
+		t.Errorf("Unexpected rewritten content: %q", content)
+	}
+}
+
+func TestNoteLinks(t *testing.T) {
+	noteRepo, _ := newTestRepositories(t)
+
+	create := func(title, content string) *note.Note {
+		n := note.NewNote(title, content)
+		if err := noteRepo.Create(n); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+		if err := noteRepo.SaveLinks(n.ID, link.Parse(content)); err != nil {
+			t.Fatalf("failed to save links: %v", err)
+		}
+		return n
+	}
+
+	guide := create("Deploy Guide", "steps")
+	index := create("Index", "Start at [[deploy guide]], then [[#1]] and [[Runbook]].")
+
+	t.Run("outgoing links resolve by title and id", func(t *testing.T) {
+		links, err := noteRepo.GetLinks(index.ID)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(links) != 3 {
+			t.Fatalf("Expected 3 links, got %d", len(links))
+		}
+
+		broken := 0
+		for _, l := range links {
+			if l.IsBroken() {
+				broken++
+			} else if l.TargetID != guide.ID {
+				t.Errorf("Expected %s to point to #%d, got #%d", l.Ref, guide.ID, l.TargetID)
+			}
+		}
+		if broken != 1 {
+			t.Errorf("Expected only [[Runbook]] to be broken, got %d broken", broken)
+		}
+	})
+
+	t.Run("backlinks", func(t *testing.T) {
+		backlinks, _ := noteRepo.GetBacklinks(guide.ID)
+		if len(backlinks) != 2 || backlinks[0].SourceID != index.ID {
+			t.Errorf("Expected 2 backlinks from Index, got %+v", backlinks)
+		}
+	})
+
+	t.Run("creating the target fixes a broken link", func(t *testing.T) {
+		runbook := create("Runbook", "")
+
+		backlinks, _ := noteRepo.GetBacklinks(runbook.ID)
+		if len(backlinks) != 1 {
+			t.Errorf("Expected Index to link to the new Runbook, got %+v", backlinks)
+		}
+	})
+
+	t.Run("trashing the target breaks links", func(t *testing.T) {
+		if err := noteRepo.Delete(guide.ID); err != nil {
+			t.Fatalf("failed to delete note: %v", err)
+		}
+
+		links, _ := noteRepo.GetAllLinks()
+		for _, l := range links {
+			if l.Ref != "Runbook" && !l.IsBroken() {
+				t.Errorf("Expected %s to be broken, got target #%d", l.Ref, l.TargetID)
+			}
+		}
+	})
+}
+
+func TestPatchNote_RewriteLinks(t *testing.T) {
+	tests := []struct {
+		name         string
+		rewriteLinks bool
+		expected     string
+	}{
+		{name: "rewrite inbound links", rewriteLinks: true, expected: "see [[New Name]]"},
+		{name: "keep inbound links", rewriteLinks: false, expected: "see [[First Note]]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mockNoteRepo, _ := createTestHandler()
+			mockNoteRepo.notesWithTags = createTestNotes()
+
+			source := mockNoteRepo.notesWithTags[1]
+			source.Content = "see [[First Note]]"
+			mockNoteRepo.SaveLinks(source.ID, link.Parse(source.Content))
+
+			if err := h.PatchNote("1", stringPtr("New Name"), nil, nil, tt.rewriteLinks); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			if source.Content != tt.expected {
+				t.Errorf("Expected content %q, got %q", tt.expected, source.Content)
+			}
+		})
+	}
+}
+
+func TestShowLinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		run         func(h handler.Handler) error
+		setupMocks  func(*mockNoteRepository, *mockTagRepository)
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "all links",
+			run:         func(h handler.Handler) error { return h.ShowLinks(false) },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: false,
+		},
+		{
+			name:        "broken links",
+			run:         func(h handler.Handler) error { return h.ShowLinks(true) },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
+			expectError: false,
+		},
+		{
+			name:        "database error",
+			run:         func(h handler.Handler) error { return h.ShowLinks(true) },
+			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) { noteRepo.err = ErrDatabaseConnection },
+			expectError: true,
+			errorMsg:    "failed to fetch links",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mockNoteRepo, mockTagRepo := createTestHandler()
+			mockNoteRepo.notesWithTags = createTestNotes()
+			mockNoteRepo.SaveLinks(1, link.Parse("[[Second Note]] [[Missing]]"))
+			tt.setupMocks(mockNoteRepo, mockTagRepo)
+
+			err := tt.run(h)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+					return
+				}
+				if tt.errorMsg != "" && !contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error message to contain '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestLinkMigration_BackfillsLinks(t *testing.T) {
+	db := openTestDB(t)
+
+	legacy := `
+		CREATE TABLE notes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			content TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO notes (title, content) VALUES ('Guide', 'text'), ('Index', 'see [[Guide]]');
+	`
+	if _, err := db.Exec(legacy); err != nil {
+		t.Fatalf("failed to build legacy schema: %v", err)
+	}
+
+	migrateTestDB(t, db)
+
+	noteRepo, _ := repository.NewNoteRepository(db)
+	backlinks, err := noteRepo.GetBacklinks(1)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(backlinks) != 1 || backlinks[0].SourceTitle != "Index" {
+		t.Errorf("Expected a backlink from Index, got %+v", backlinks)
+	}
+}
@@ -0,0 +1,337 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/property"
+	"github.com/matheuzgomes/Snip/internal/render"
+	"github.com/matheuzgomes/Snip/internal/repository"
+)
+
+func TestParseProperty(t *testing.T) {
+	tests := []struct {
+		raw         string
+		kind        property.Type
+		value       string
+		expectError bool
+	}{
+		{raw: "done", kind: property.String, value: "done"},
+		{raw: "2", kind: property.Number, value: "2"},
+		{raw: "-2.50", kind: property.Number, value: "-2.5"},
+		{raw: "true", kind: property.Bool, value: "true"},
+		{raw: "2025-03-14", kind: property.Date, value: "2025-03-14"},
+		{raw: "2025-13-01", kind: property.String, value: "2025-13-01"},
+		{raw: "[ana, rui, ]", kind: property.List, value: `["ana","rui"]`},
+		{raw: `"2"`, kind: property.String, value: "2"},
+		{raw: "v1.2", kind: property.String, value: "v1.2"},
+		{raw: " ", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			p, err := property.Parse("key", tt.raw)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if p.Type != tt.kind || p.Value != tt.value {
+				t.Errorf("Expected %s %q, got %s %q", tt.kind, tt.value, p.Type, p.Value)
+			}
+		})
+	}
+
+	for _, key := range []string{"tags", "two words", "1st"} {
+		if _, err := property.Parse(key, "x"); err == nil {
+			t.Errorf("Expected key '%s' to be rejected", key)
+		}
+	}
+}
+
+func TestConditionMatch(t *testing.T) {
+	var properties property.Properties
+	for _, pair := range [][2]string{{"priority", "2"}, {"status", "Done"}, {"due", "2025-03-14"}, {"urgent", "false"}, {"owners", "[ana, rui]"}} {
+		p, err := property.Parse(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("failed to parse property: %v", err)
+		}
+		properties = properties.Set(p)
+	}
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{condition: "priority", expected: true},
+		{condition: "estimate", expected: false},
+		{condition: "priority>=2", expected: true},
+		{condition: "priority>10", expected: false},
+		{condition: "priority<10", expected: true},
+		{condition: "status=done", expected: true},
+		{condition: "status!=done", expected: false},
+		{condition: "estimate!=3", expected: true},
+		{condition: "due<2025-04-01", expected: true},
+		{condition: "due>2025-04-01", expected: false},
+		{condition: "urgent=false", expected: true},
+		{condition: "urgent>false", expected: false},
+		{condition: "owners=ana", expected: true},
+		{condition: "owners!=ana", expected: false},
+		{condition: "owners=bob", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			c, err := property.ParseCondition(tt.condition)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if got := c.Match(properties); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	for _, s := range []string{"", "priority>=", "=2", "a b=1"} {
+		if _, err := property.ParseCondition(s); err == nil {
+			t.Errorf("Expected condition '%s' to be rejected", s)
+		}
+	}
+}
+
+func TestSetAndUnsetProperties(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	createFrontMatterNotes(t, noteRepo, tagRepo)
+
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+
+	if err := h.SetProperties("sprint-review", []string{"status=open", "priority=2", "owners=[ana, rui]"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := h.SetProperties("1", []string{"status=done"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := h.SetProperties("1", []string{"status"}); err == nil || !contains(err.Error(), "use key=value") {
+		t.Errorf("Expected a key=value error, got %v", err)
+	}
+
+	n, err := noteRepo.GetByID(1)
+	if err != nil {
+		t.Fatalf("failed to fetch note: %v", err)
+	}
+	if len(n.Properties) != 3 || n.Properties[0].Key != "owners" || n.Properties.Get("status").Value != "done" {
+		t.Errorf("Expected owners, priority and status=done, got %v", n.Properties)
+	}
+
+	if err := h.UnsetProperties("1", []string{"owners", "missing"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := h.UnsetProperties("1", []string{"missing"}); err == nil {
+		t.Errorf("Expected an error when no property is removed")
+	}
+
+	n, _ = noteRepo.GetByID(1)
+	if len(n.Properties) != 2 || n.Properties.Get("owners") != nil {
+		t.Errorf("Expected owners to be removed, got %v", n.Properties)
+	}
+}
+
+func TestListNotesWhere(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	createFrontMatterNotes(t, noteRepo, tagRepo)
+
+	setup := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+	if err := setup.SetProperties("1", []string{"priority=3", "status=open"}); err != nil {
+		t.Fatalf("failed to set properties: %v", err)
+	}
+	if err := setup.SetProperties("2", []string{"priority=1"}); err != nil {
+		t.Fatalf("failed to set properties: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		where    []string
+		expected []string
+	}{
+		{name: "no condition", expected: []string{"Taken", "Sprint review"}},
+		{name: "number", where: []string{"priority>=2"}, expected: []string{"Sprint review"}},
+		{name: "every condition", where: []string{"priority>=1", "status!=open"}, expected: []string{"Taken"}},
+		{name: "none match", where: []string{"priority>5"}, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := handler.NewHandler(noteRepo, tagRepo, handler.WithOutput(render.New(render.FormatJSON, &buf)))
+
+			if err := h.ListNotes(false, false, nil, false, tt.where, ""); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			var notes []note.NoteWithTags
+			if err := json.Unmarshal(buf.Bytes(), &notes); err != nil {
+				t.Fatalf("Expected JSON list, got %q: %v", buf.String(), err)
+			}
+			titles := []string{}
+			for _, n := range notes {
+				titles = append(titles, n.Title)
+			}
+			if strings.Join(titles, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, titles)
+			}
+		})
+	}
+
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+	if err := h.ListNotes(false, false, nil, false, []string{"priority>>2"}, ""); err == nil {
+		t.Errorf("Expected an invalid condition to be rejected")
+	}
+}
+
+func TestRecentNotesProperties(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	createFrontMatterNotes(t, noteRepo, tagRepo)
+
+	setup := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+	if err := setup.SetProperties("1", []string{"status=open"}); err != nil {
+		t.Fatalf("failed to set properties: %v", err)
+	}
+
+	var buf bytes.Buffer
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithOutput(render.New(render.FormatJSON, &buf)))
+	if err := h.GetRecentNotes(10); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var notes []note.NoteWithTags
+	if err := json.Unmarshal(buf.Bytes(), &notes); err != nil {
+		t.Fatalf("Expected JSON list, got %q: %v", buf.String(), err)
+	}
+	for _, n := range notes {
+		if n.ID == 1 && n.Properties.Get("status") == nil {
+			t.Errorf("Expected recent notes to carry their properties, got %v", n.Properties)
+		}
+	}
+}
+
+func TestPurgeNoteProperties(t *testing.T) {
+	db := openTestDB(t)
+	migrateTestDB(t, db)
+	noteRepo, _ := repository.NewNoteRepository(db)
+	tagRepo, _ := repository.NewTagRepository(db)
+	createFrontMatterNotes(t, noteRepo, tagRepo)
+
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+	if err := h.SetProperties("1", []string{"status=open", "priority=2"}); err != nil {
+		t.Fatalf("failed to set properties: %v", err)
+	}
+	if err := noteRepo.Delete(1); err != nil {
+		t.Fatalf("failed to trash note: %v", err)
+	}
+	if _, err := noteRepo.Purge(nil); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var orphans int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM note_properties WHERE note_id = 1`).Scan(&orphans); err != nil {
+		t.Fatalf("failed to count properties: %v", err)
+	}
+	if orphans != 0 {
+		t.Errorf("Expected the properties to go with the note, got %d rows", orphans)
+	}
+}
+
+func TestPropertiesExportImport(t *testing.T) {
+	for _, format := range []string{"json", "markdown"} {
+		t.Run(format, func(t *testing.T) {
+			noteRepo, tagRepo := newTestRepositories(t)
+			createFrontMatterNotes(t, noteRepo, tagRepo)
+
+			h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+			if err := h.SetProperties("1", []string{"status=done", "priority=2", "due=2025-03-14", "owners=[ana, rui]", "version='2'"}); err != nil {
+				t.Fatalf("failed to set properties: %v", err)
+			}
+
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			exportDir := filepath.Join(home, "export")
+			if err := os.MkdirAll(exportDir, 0755); err != nil {
+				t.Fatalf("failed to create export dir: %v", err)
+			}
+			if _, err := noteRepo.ExportNotes(exportDir, nil, format); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			importRepo, importTagRepo := newTestRepositories(t)
+			imported := handler.NewHandler(importRepo, importTagRepo, handler.Quiet())
+			if err := imported.ImportNotes("export", false); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			n, err := importRepo.GetByID(1)
+			if err != nil {
+				t.Fatalf("failed to fetch imported note: %v", err)
+			}
+
+			expected := map[string]property.Type{
+				"due":      property.Date,
+				"owners":   property.List,
+				"priority": property.Number,
+				"status":   property.String,
+				"version":  property.String,
+			}
+			if len(n.Properties) != len(expected) {
+				t.Fatalf("Expected %d properties, got %v", len(expected), n.Properties)
+			}
+			for _, p := range n.Properties {
+				if expected[p.Key] != p.Type {
+					t.Errorf("Expected %s to be a %s, got %s", p.Key, expected[p.Key], p.Type)
+				}
+			}
+			if n.Properties.Get("version").Value != "2" || n.Properties.Get("owners").String() != "ana, rui" {
+				t.Errorf("Unexpected values %v", n.Properties)
+			}
+		})
+	}
+}
+
+func TestImportMarkdownFrontMatter(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, "vault")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	content := "---\ntitle: Weekly sync\ntags: [work, meetings]\nstatus: open\n---\nagenda\n"
+	if err := os.WriteFile(filepath.Join(dir, "weekly.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	noteRepo, tagRepo := newTestRepositories(t)
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+	if err := h.ImportNotes("vault", false); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	n, err := noteRepo.GetByID(1)
+	if err != nil {
+		t.Fatalf("failed to fetch imported note: %v", err)
+	}
+	if n.Title != "Weekly sync" || n.Content != "agenda\n" || len(n.Tags) != 2 {
+		t.Errorf("Expected the front matter to set title and tags, got %+v", n)
+	}
+	if p := n.Properties.Get("status"); p == nil || p.Value != "open" {
+		t.Errorf("Expected status=open, got %v", n.Properties)
+	}
+}
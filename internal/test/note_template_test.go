@@ -0,0 +1,184 @@
+package test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/notetemplate"
+	"github.com/matheuzgomes/Snip/internal/placeholder"
+)
+
+const postmortemTemplate = `---
+description: Blameless postmortem
+tags: [postmortem, incident]
+prompts:
+  - name: service
+    prompt: Which service was affected?
+    default: api
+---
+# {{title}}
+
+{{date}}, {{user}}: {{service}} at severity {{sev:2}}, owner {{owner}}
+`
+
+func TestPlaceholders(t *testing.T) {
+	text := "kubectl -n {{ namespace }} rollout restart deploy/{{name:api}} {{name}} {{date}} {{not a placeholder}}"
+
+	found := placeholder.Find(text)
+	if len(found) != 3 {
+		t.Fatalf("Expected 3 placeholders, got %+v", found)
+	}
+	if found[0].Name != "namespace" || found[0].HasDefault {
+		t.Errorf("Expected namespace without default, got %+v", found[0])
+	}
+	if found[1].Name != "name" || found[1].Default != "api" {
+		t.Errorf("Expected name with default 'api', got %+v", found[1])
+	}
+
+	expanded, missing := placeholder.Expand(text, map[string]string{"name": "web"})
+	if expanded != "kubectl -n {{ namespace }} rollout restart deploy/web web {{date}} {{not a placeholder}}" {
+		t.Errorf("Unexpected expansion %q", expanded)
+	}
+	if !slices.Equal(missing, []string{"namespace", "date"}) {
+		t.Errorf("Expected namespace and date to be missing, got %v", missing)
+	}
+
+	expanded, _ = placeholder.Expand("{{name:api}}", nil)
+	if expanded != "api" {
+		t.Errorf("Expected the default to be used, got %q", expanded)
+	}
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		front string
+		body  string
+		ok    bool
+	}{
+		{name: "front matter", text: "---\ntags: [a]\n---\nbody\n", front: "tags: [a]\n", body: "body\n", ok: true},
+		{name: "empty front matter", text: "---\n---\nbody", front: "", body: "body", ok: true},
+		{name: "windows line endings", text: "---\r\ntitle: x\r\n---\r\nbody", front: "title: x\r\n", body: "body", ok: true},
+		{name: "no front matter", text: "# Title\n---\n", body: "# Title\n---\n"},
+		{name: "unclosed", text: "---\ntags: [a]\nbody", body: "---\ntags: [a]\nbody"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			front, body, ok := note.SplitFrontMatter(tt.text)
+			if front != tt.front || body != tt.body || ok != tt.ok {
+				t.Errorf("Expected (%q, %q, %v), got (%q, %q, %v)", tt.front, tt.body, tt.ok, front, body, ok)
+			}
+		})
+	}
+}
+
+func TestTemplateStore(t *testing.T) {
+	store := notetemplate.NewStore(t.TempDir())
+
+	tmpl, err := store.Create("postmortem", postmortemTemplate)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if tmpl.Description != "Blameless postmortem" || !slices.Equal(tmpl.Tags, []string{"postmortem", "incident"}) {
+		t.Errorf("Expected the front matter to be read, got %+v", tmpl)
+	}
+	if strings.HasPrefix(tmpl.Body, "---") {
+		t.Errorf("Expected the body without front matter, got %q", tmpl.Body)
+	}
+
+	var names []string
+	for _, v := range tmpl.Variables() {
+		names = append(names, v.Name)
+	}
+	if !slices.Equal(names, []string{"service", "sev", "owner"}) {
+		t.Errorf("Expected the variables service, sev and owner, got %v", names)
+	}
+	if tmpl.Question("service") != "Which service was affected?" || tmpl.Question("owner") != "owner" {
+		t.Errorf("Unexpected questions %q and %q", tmpl.Question("service"), tmpl.Question("owner"))
+	}
+
+	if _, err := store.Create("postmortem", "x"); !errors.Is(err, notetemplate.ErrAlreadyExists) {
+		t.Errorf("Expected '%v', got '%v'", notetemplate.ErrAlreadyExists, err)
+	}
+	if _, err := store.Create("broken", "---\ntags: [a\n---\n"); err == nil {
+		t.Errorf("Expected invalid front matter to be rejected")
+	}
+	if _, err := store.Create("../escape", "x"); err == nil {
+		t.Errorf("Expected an invalid name to be rejected")
+	}
+	if _, err := store.Create("adr", "# {{title}}\n"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	templates, err := store.List()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "adr" || templates[1].Name != "postmortem" {
+		t.Errorf("Expected adr and postmortem in name order, got %+v", templates)
+	}
+
+	if err := store.Remove("adr"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if _, err := store.Get("adr"); !errors.Is(err, notetemplate.ErrNotFound) {
+		t.Errorf("Expected '%v', got '%v'", notetemplate.ErrNotFound, err)
+	}
+}
+
+func TestCreateNoteFromTemplate(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+
+	cfg := config.Default()
+	cfg.Editor = "true"
+	h := handler.NewHandler(noteRepo, tagRepo, handler.WithConfig(cfg), handler.Quiet())
+
+	tmpl, err := notetemplate.Parse("postmortem", postmortemTemplate)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	t.Run("fills variables and tags", func(t *testing.T) {
+		vars := map[string]string{"owner": "ana"}
+		if err := h.CreateNoteFromTemplate("API outage", tmpl, vars, stringPtr("sre")); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		created, err := noteRepo.GetByID(1)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		date := time.Now().Format("2006-01-02")
+		if !strings.HasPrefix(created.Content, "# API outage\n\n"+date+", ") ||
+			!strings.HasSuffix(created.Content, ": api at severity 2, owner ana\n") {
+			t.Errorf("Unexpected content %q", created.Content)
+		}
+		for _, tag := range []string{"postmortem", "incident", "sre"} {
+			if !slices.Contains(created.Tags, tag) {
+				t.Errorf("Expected tag '%s', got %v", tag, created.Tags)
+			}
+		}
+	})
+
+	t.Run("missing variable without a terminal", func(t *testing.T) {
+		err := h.CreateNoteFromTemplate("Another outage", tmpl, nil, nil)
+		if err == nil || !contains(err.Error(), "pass --var owner=value") {
+			t.Errorf("Expected a missing variable error, got %v", err)
+		}
+	})
+
+	t.Run("invalid title", func(t *testing.T) {
+		if err := h.CreateNoteFromTemplate("", tmpl, nil, nil); err == nil {
+			t.Errorf("Expected an error for an empty title")
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+)
+
+func TestUseNote(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+
+	snippet := "kubectl -n {{namespace}} rollout restart deploy/{{name:api}} # {{namespace}}"
+	if err := h.CreateNote("Restart deploy", &snippet, nil, "bash"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		vars        map[string]string
+		expected    string
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:     "defaults fill what is not given",
+			vars:     map[string]string{"namespace": "prod"},
+			expected: "kubectl -n prod rollout restart deploy/api # prod",
+		},
+		{
+			name:     "vars override defaults",
+			vars:     map[string]string{"namespace": "prod", "name": "web"},
+			expected: "kubectl -n prod rollout restart deploy/web # prod",
+		},
+		{
+			name:        "missing value without a terminal",
+			vars:        map[string]string{"name": "web"},
+			expectError: true,
+			errorMsg:    "pass --set namespace=value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "restart.sh")
+
+			err := h.UseNote("restart-deploy", tt.vars, out, false, false)
+			if tt.expectError {
+				if err == nil || !contains(err.Error(), tt.errorMsg) {
+					t.Fatalf("Expected error containing '%s', got %v", tt.errorMsg, err)
+				}
+				if _, statErr := os.Stat(out); statErr == nil {
+					t.Errorf("Expected no file to be written")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			written, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("failed to read written file: %v", err)
+			}
+			if string(written) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, written)
+			}
+		})
+	}
+
+	n, _ := noteRepo.GetByID(1)
+	if n.Content != snippet {
+		t.Errorf("Expected the note to be left alone, got %q", n.Content)
+	}
+}
+
+func TestUseNoteRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("runs a POSIX shell")
+	}
+	t.Setenv("SHELL", "/bin/sh")
+
+	noteRepo, tagRepo := newTestRepositories(t)
+	h := handler.NewHandler(noteRepo, tagRepo, handler.Quiet())
+
+	snippet := "echo {{greeting:hello}} > {{out}}"
+	if err := h.CreateNote("Greet", &snippet, nil, ""); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	out := filepath.Join(t.TempDir(), "greeting.txt")
+	vars := map[string]string{"out": out}
+
+	if err := h.UseNote("1", vars, "", true, false); err == nil || !contains(err.Error(), "canceled") {
+		t.Errorf("Expected the run to be canceled without confirmation, got %v", err)
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Fatalf("Expected nothing to run before confirmation")
+	}
+
+	if err := h.UseNote("1", vars, "", true, true); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil || string(got) != "hello\n" {
+		t.Errorf("Expected the snippet to run, got %q (%v)", got, err)
+	}
+
+	if err := h.UseNote("1", map[string]string{"out": "/nonexistent/dir/x"}, "", true, true); err == nil || !contains(err.Error(), "snippet failed") {
+		t.Errorf("Expected a failing snippet to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/notebook"
+)
+
+func TestNotebookStore(t *testing.T) {
+	root := t.TempDir()
+	store := notebook.NewStore(root)
+	t.Setenv("SNIP_DB", "")
+
+	t.Run("default notebook keeps the legacy path", func(t *testing.T) {
+		nb, err := store.Resolve("")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if nb.Name != notebook.DefaultName || nb.DBPath != filepath.Join(root, "notes.db") {
+			t.Errorf("Expected default notebook at %s, got %+v", root, nb)
+		}
+	})
+
+	t.Run("create, use and resolve", func(t *testing.T) {
+		work, err := store.Create("work")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		if _, err := store.Create("work"); !errors.Is(err, notebook.ErrAlreadyExists) {
+			t.Errorf("Expected ErrAlreadyExists, got %v", err)
+		}
+
+		if err := store.Use("work"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		nb, _ := store.Resolve("")
+		if nb.DBPath != work.DBPath {
+			t.Errorf("Expected current notebook to be work, got %+v", nb)
+		}
+
+		nb, _ = store.Resolve(notebook.DefaultName)
+		if nb.Name != notebook.DefaultName {
+			t.Errorf("Expected the flag to win over the current notebook, got %+v", nb)
+		}
+	})
+
+	t.Run("SNIP_DB overrides the current notebook", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "other.db")
+		t.Setenv("SNIP_DB", path)
+
+		nb, err := store.Resolve("")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if nb.DBPath != path || nb.BackupDir() != filepath.Join(filepath.Dir(path), "backups") {
+			t.Errorf("Expected notebook at %s, got %+v", path, nb)
+		}
+
+		nb, _ = store.Resolve("work")
+		if nb.Name != "work" {
+			t.Errorf("Expected the flag to win over SNIP_DB, got %+v", nb)
+		}
+	})
+
+	t.Run("invalid and unknown names", func(t *testing.T) {
+		if _, err := store.Create("../escape"); err == nil {
+			t.Errorf("Expected invalid name to be rejected")
+		}
+		if _, err := store.Resolve("missing"); !errors.Is(err, notebook.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("remove falls back to default", func(t *testing.T) {
+		if err := store.Remove(notebook.DefaultName); err == nil {
+			t.Errorf("Expected the default notebook to be protected")
+		}
+
+		if err := store.Remove("work"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		current, _ := store.Current()
+		if current != notebook.DefaultName {
+			t.Errorf("Expected default to be current again, got %s", current)
+		}
+
+		notebooks, _ := store.List()
+		if len(notebooks) != 1 {
+			t.Errorf("Expected only the default notebook, got %d", len(notebooks))
+		}
+	})
+}
+
+func TestBackupDatabase_PerNotebook(t *testing.T) {
+	store := notebook.NewStore(t.TempDir())
+	work, err := store.Create("work")
+	if err != nil {
+		t.Fatalf("failed to create notebook: %v", err)
+	}
+	if err := os.WriteFile(work.DBPath, []byte("sqlite"), 0644); err != nil {
+		t.Fatalf("failed to write database: %v", err)
+	}
+
+	h := handler.NewHandler(&mockNoteRepository{}, &mockTagRepository{}, handler.WithNotebook(work))
+	if err := h.BackupDatabase(); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	backups, err := os.ReadDir(work.BackupDir())
+	if err != nil || len(backups) != 1 {
+		t.Errorf("Expected one backup in %s, got %d (%v)", work.BackupDir(), len(backups), err)
+	}
+}
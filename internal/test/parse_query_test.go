@@ -0,0 +1,110 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/search"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Run("terms, fields and groups", func(t *testing.T) {
+		query, err := search.Parse(`tag:work -tag:old title:"deploy guide" "exact phrase" blog OR runbook`)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		if len(query.Groups) != 5 {
+			t.Fatalf("Expected 5 groups, got %d", len(query.Groups))
+		}
+
+		tagOld := query.Groups[1].Terms[0]
+		if tagOld.Kind != search.KindTag || !tagOld.Negated || tagOld.Value != "old" {
+			t.Errorf("Expected negated tag 'old', got %+v", tagOld)
+		}
+
+		title := query.Groups[2].Terms[0]
+		if title.Kind != search.KindTitle || title.Value != "deploy guide" {
+			t.Errorf("Expected title 'deploy guide', got %+v", title)
+		}
+
+		if len(query.Groups[4].Terms) != 2 {
+			t.Errorf("Expected OR to group 2 terms, got %d", len(query.Groups[4].Terms))
+		}
+	})
+
+	t.Run("date ranges", func(t *testing.T) {
+		query, err := search.Parse("created:>=2025-01-01 updated:<7d")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		created := query.Groups[0].Terms[0]
+		if created.From == nil || created.To != nil || created.From.Format("2006-01-02") != "2025-01-01" {
+			t.Errorf("Expected created from 2025-01-01 with no upper bound, got %+v", created)
+		}
+
+		updated := query.Groups[1].Terms[0]
+		if updated.From == nil || updated.To != nil {
+			t.Errorf("Expected updated to have only a lower bound, got %+v", updated)
+		}
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		query, err := search.Parse("   ")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if !query.IsEmpty() {
+			t.Errorf("Expected empty query")
+		}
+	})
+
+	errorCases := []struct {
+		name  string
+		input string
+		pos   int
+		msg   string
+	}{
+		{"unterminated quote", `deploy "half open`, 7, "unterminated quote"},
+		{"unknown field", "foo:bar", 0, "unknown field"},
+		{"missing value", "tag:", 4, "missing value"},
+		{"bad date", "created:>yesterday", 9, "invalid"},
+		{"dangling OR", "deploy OR", 7, "OR needs a term"},
+		{"leading OR", "OR deploy", 0, "OR needs a term"},
+		{"lone dash", "deploy -", 7, "must be followed"},
+	}
+
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := search.Parse(tt.input)
+
+			var syntaxErr *search.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected a syntax error, got %v", err)
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("Expected error at %d, got %d", tt.pos, syntaxErr.Pos)
+			}
+			if !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("Expected error message to contain '%s', got '%s'", tt.msg, err.Error())
+			}
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	since, err := search.ParseSince("2025-01-01")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if expected := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); !since.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, since)
+	}
+
+	if _, err := search.ParseSince("2x"); err == nil {
+		t.Errorf("Expected an invalid duration to be rejected")
+	}
+}
@@ -57,7 +57,7 @@ func TestPatchNote(t *testing.T) {
 			tag:         nil,
 			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
 			expectError: true,
-			errorMsg:    "invalid note ID",
+			errorMsg:    "no note matches",
 		},
 		{
 			name:  "empty title",
@@ -111,7 +111,7 @@ func TestPatchNote(t *testing.T) {
 			h, mockNoteRepo, mockTagRepo := createTestHandler()
 			tt.setupMocks(mockNoteRepo, mockTagRepo)
 
-			err := h.PatchNote(tt.idStr, tt.title, tt.tag)
+			err := h.PatchNote(tt.idStr, tt.title, tt.tag, nil, false)
 
 			if tt.expectError {
 				if err == nil {
@@ -136,7 +136,7 @@ func TestPatchNote_EdgeCases(t *testing.T) {
 		mockNoteRepo.err = nil
 		mockNoteRepo.notesWithTags = createTestNotes()
 
-		err := h.PatchNote("-1", stringPtr("Patched Title"), nil)
+		err := h.PatchNote("-1", stringPtr("Patched Title"), nil, nil, false)
 
 		if err == nil {
 			t.Errorf("Expected error for negative ID, got none")
@@ -148,7 +148,7 @@ func TestPatchNote_EdgeCases(t *testing.T) {
 		mockNoteRepo.err = nil
 		mockNoteRepo.notesWithTags = createTestNotes()
 
-		err := h.PatchNote("0", stringPtr("Patched Title"), nil)
+		err := h.PatchNote("0", stringPtr("Patched Title"), nil, nil, false)
 
 		if err == nil {
 			t.Errorf("Expected error for zero ID, got none")
@@ -161,7 +161,7 @@ func TestPatchNote_EdgeCases(t *testing.T) {
 		mockNoteRepo.notesWithTags = createTestNotes()
 
 		longTitle := "This is a very long title that might cause issues in some systems but should still be valid for our note patch"
-		err := h.PatchNote("1", stringPtr(longTitle), nil)
+		err := h.PatchNote("1", stringPtr(longTitle), nil, nil, false)
 
 		if err != nil {
 			t.Errorf("Expected no error for long title, got: %v", err)
@@ -173,7 +173,7 @@ func TestPatchNote_EdgeCases(t *testing.T) {
 		mockNoteRepo.err = nil
 		mockNoteRepo.notesWithTags = createTestNotes()
 
-		err := h.PatchNote("1", nil, nil)
+		err := h.PatchNote("1", nil, nil, nil, false)
 
 		if err != nil {
 			t.Errorf("Expected no error for nil title and tag, got: %v", err)
@@ -189,7 +189,7 @@ func BenchmarkPatchNote(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := h.PatchNote("1", stringPtr("Patched Title"), stringPtr("new-tag"))
+		err := h.PatchNote("1", stringPtr("Patched Title"), stringPtr("new-tag"), nil, false)
 		if err != nil {
 			b.Fatalf("PatchNote failed: %v", err)
 		}
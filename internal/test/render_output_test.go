@@ -0,0 +1,359 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/config"
+	"github.com/matheuzgomes/Snip/internal/database"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/notebook"
+	"github.com/matheuzgomes/Snip/internal/notetemplate"
+	"github.com/matheuzgomes/Snip/internal/render"
+	"github.com/matheuzgomes/Snip/internal/tag"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    render.Format
+		expectError bool
+	}{
+		{value: "", expected: render.FormatTable},
+		{value: "table", expected: render.FormatTable},
+		{value: "JSON", expected: render.FormatJSON},
+		{value: "yml", expected: render.FormatYAML},
+		{value: "tsv", expected: render.FormatTSV},
+		{value: "xml", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			format, err := render.ParseFormat(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if format != tt.expected {
+				t.Errorf("Expected format %s, got %s", tt.expected, format)
+			}
+		})
+	}
+}
+
+func TestRenderFormats(t *testing.T) {
+	tags := []*tag.Tag{
+		{ID: 1, Name: "work", SubtreeCount: 1},
+		{ID: 2, Name: "work/infra", ParentID: 1, NoteCount: 1, SubtreeCount: 1},
+	}
+
+	tests := []struct {
+		name     string
+		format   render.Format
+		v        any
+		expected string
+	}{
+		{
+			name:     "yaml keeps json field names",
+			format:   render.FormatYAML,
+			v:        tags[1:],
+			expected: "- id: 2\n  name: work/infra\n  parent_id: 1\n  note_count: 1\n  subtree_count: 1\n",
+		},
+		{
+			name:     "tsv header covers omitted fields",
+			format:   render.FormatTSV,
+			v:        tags,
+			expected: "id\tname\tnote_count\tsubtree_count\tparent_id\n1\twork\t0\t1\t\n2\twork/infra\t1\t1\t1\n",
+		},
+		{
+			name:   "tsv joins lists and escapes tabs",
+			format: render.FormatTSV,
+			v: map[string]any{
+				"content": "a\tb\nc",
+				"tags":    []string{"go", "work"},
+			},
+			expected: "content\ttags\na\\tb\\nc\tgo,work\n",
+		},
+		{
+			name:     "json does not escape html",
+			format:   render.FormatJSON,
+			v:        render.ErrorResult{Error: "a -> b"},
+			expected: "{\n  \"error\": \"a -> b\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := render.New(tt.format, &buf).Render(tt.v); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected output:\n%q\ngot:\n%q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := render.New(render.FormatYAML, &buf).Error(errors.New("note not found")); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if buf.String() != "error: note not found\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
+
+func createStructuredTestHandler(buf *bytes.Buffer) (handler.Handler, *mockNoteRepository, *mockTagRepository) {
+	mockNoteRepo := &mockNoteRepository{}
+	mockTagRepo := &mockTagRepository{}
+
+	h := handler.NewHandler(mockNoteRepo, mockTagRepo, handler.WithOutput(render.New(render.FormatJSON, buf)))
+	return h, mockNoteRepo, mockTagRepo
+}
+
+func TestStructuredOutput(t *testing.T) {
+	tests := []struct {
+		name  string
+		run   func(h handler.Handler) error
+		check func(t *testing.T, output []byte)
+	}{
+		{
+			name: "list renders notes",
+			run: func(h handler.Handler) error {
+				return h.ListNotes(false, false, nil, false, nil, "")
+			},
+			check: func(t *testing.T, output []byte) {
+				var notes []map[string]any
+				if err := json.Unmarshal(output, &notes); err != nil {
+					t.Fatalf("Expected JSON list, got %q: %v", output, err)
+				}
+				if len(notes) != 3 || notes[0]["title"] == nil {
+					t.Errorf("Unexpected notes: %v", notes)
+				}
+			},
+		},
+		{
+			name: "show renders links and backlinks",
+			run: func(h handler.Handler) error {
+				return h.GetNote("1", false, false)
+			},
+			check: func(t *testing.T, output []byte) {
+				var details map[string]any
+				if err := json.Unmarshal(output, &details); err != nil {
+					t.Fatalf("Expected JSON object, got %q: %v", output, err)
+				}
+				if details["title"] != "First Note" {
+					t.Errorf("Expected title 'First Note', got %v", details["title"])
+				}
+				if _, ok := details["backlinks"].([]any); !ok {
+					t.Errorf("Expected backlinks list, got %v", details["backlinks"])
+				}
+			},
+		},
+		{
+			name: "find drops match markers",
+			run: func(h handler.Handler) error {
+				return h.FindNotes("First")
+			},
+			check: func(t *testing.T, output []byte) {
+				if bytes.ContainsAny(output, "\x02\x03") {
+					t.Errorf("Expected no match markers in %q", output)
+				}
+				if !strings.Contains(string(output), `"highlight": "First Note"`) {
+					t.Errorf("Expected highlight in %s", output)
+				}
+			},
+		},
+		{
+			name: "empty link list renders an empty array",
+			run: func(h handler.Handler) error {
+				return h.ShowLinks(false)
+			},
+			check: func(t *testing.T, output []byte) {
+				if strings.TrimSpace(string(output)) != "[]" {
+					t.Errorf("Expected [], got %q", output)
+				}
+			},
+		},
+		{
+			name: "changes render a result",
+			run: func(h handler.Handler) error {
+				return h.DeleteNote("2")
+			},
+			check: func(t *testing.T, output []byte) {
+				var result handler.Result
+				if err := json.Unmarshal(output, &result); err != nil {
+					t.Fatalf("Expected JSON result, got %q: %v", output, err)
+				}
+				if result.Status != "trashed" || result.ID != 2 {
+					t.Errorf("Unexpected result: %+v", result)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h, mockNoteRepo, _ := createStructuredTestHandler(&buf)
+			mockNoteRepo.notesWithTags = createTestNotes()
+
+			if err := tt.run(h); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			tt.check(t, buf.Bytes())
+		})
+	}
+}
+
+func TestTableOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(h handler.Handler) error
+		expected string
+	}{
+		{
+			name:     "list",
+			run:      func(h handler.Handler) error { return h.ListNotes(false, false, nil, false, nil, "") },
+			expected: "● #1 First Note",
+		},
+		{
+			name:     "show",
+			run:      func(h handler.Handler) error { return h.GetNote("1", false, false) },
+			expected: "This is the first note content",
+		},
+		{
+			name:     "empty link list",
+			run:      func(h handler.Handler) error { return h.ShowLinks(false) },
+			expected: "No links found.",
+		},
+		{
+			name:     "changes",
+			run:      func(h handler.Handler) error { return h.DeleteNote("2") },
+			expected: "snip trash restore 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mockNoteRepo := &mockNoteRepository{notesWithTags: createTestNotes()}
+			h := handler.NewHandler(mockNoteRepo, &mockTagRepository{}, handler.WithOutput(render.New(render.FormatTable, &buf)))
+
+			if err := tt.run(h); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.expected) {
+				t.Errorf("Expected output to contain %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestStructuredOutput_OtherHandlers(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, out *render.Renderer) error
+		rows int
+	}{
+		{
+			name: "notebook create",
+			run: func(t *testing.T, out *render.Renderer) error {
+				return handler.NewNotebookHandler(notebook.NewStore(t.TempDir()), out).CreateNotebook("work")
+			},
+		},
+		{
+			name: "notebook list",
+			run: func(t *testing.T, out *render.Renderer) error {
+				return handler.NewNotebookHandler(notebook.NewStore(t.TempDir()), out).ListNotebooks()
+			},
+			rows: 1,
+		},
+		{
+			name: "config list",
+			run: func(t *testing.T, out *render.Renderer) error {
+				path := filepath.Join(t.TempDir(), "config.yaml")
+				return handler.NewConfigHandler(path, config.Default(), out).ListConfig()
+			},
+			rows: len(config.Keys()),
+		},
+		{
+			name: "config set",
+			run: func(t *testing.T, out *render.Renderer) error {
+				path := filepath.Join(t.TempDir(), "config.yaml")
+				return handler.NewConfigHandler(path, config.Default(), out).SetConfig("rows_limit", "3")
+			},
+		},
+		{
+			name: "db migrate",
+			run: func(t *testing.T, out *render.Renderer) error {
+				return handler.NewDatabaseHandler(openTestDB(t), out).Migrate()
+			},
+		},
+		{
+			name: "db migrate status",
+			run: func(t *testing.T, out *render.Renderer) error {
+				return handler.NewDatabaseHandler(openTestDB(t), out).ShowMigrationStatus()
+			},
+			rows: database.Latest(),
+		},
+		{
+			name: "template list",
+			run: func(t *testing.T, out *render.Renderer) error {
+				store := notetemplate.NewStore(t.TempDir())
+				if _, err := store.Create("postmortem", postmortemTemplate); err != nil {
+					t.Fatalf("failed to create template: %v", err)
+				}
+				return handler.NewTemplateHandler(store, config.Default(), out).ListTemplates()
+			},
+			rows: 1,
+		},
+		{
+			name: "template remove",
+			run: func(t *testing.T, out *render.Renderer) error {
+				store := notetemplate.NewStore(t.TempDir())
+				if _, err := store.Create("postmortem", postmortemTemplate); err != nil {
+					t.Fatalf("failed to create template: %v", err)
+				}
+				return handler.NewTemplateHandler(store, config.Default(), out).RemoveTemplate("postmortem", true)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.run(t, render.New(render.FormatJSON, &buf)); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			if tt.rows == 0 {
+				var result handler.Result
+				if err := json.Unmarshal(buf.Bytes(), &result); err != nil || result.Status == "" {
+					t.Fatalf("Expected only a JSON result, got %q: %v", buf.String(), err)
+				}
+				return
+			}
+
+			var rows []map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+				t.Fatalf("Expected only a JSON list, got %q: %v", buf.String(), err)
+			}
+			if len(rows) != tt.rows {
+				t.Errorf("Expected %d rows, got %d", tt.rows, len(rows))
+			}
+		})
+	}
+}
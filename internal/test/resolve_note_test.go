@@ -0,0 +1,137 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"Meeting Notes", "meeting-notes"},
+		{"  Deploy: api / v2!  ", "deploy-api-v2"},
+		{"Café résumé", "café-résumé"},
+		{"2025", "note-2025"},
+		{"???", "note"},
+		{"This is a very long title that goes well past the fifty character limit", "this-is-a-very-long-title-that-goes-well-past-the"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := note.Slugify(tt.title); got != tt.expected {
+				t.Errorf("Expected slug '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveNote(t *testing.T) {
+	secondHash := note.ContentHash("This is the second note content")[:note.ShortHashLength]
+
+	tests := []struct {
+		name        string
+		ref         string
+		expectError bool
+		errorMsg    string
+	}{
+		{name: "numeric id", ref: "2"},
+		{name: "slug", ref: "third-note"},
+		{name: "slug ignores case", ref: "Third-Note"},
+		{name: "exact title", ref: "Second Note"},
+		{name: "title prefix", ref: "sec"},
+		{name: "content hash prefix", ref: secondHash},
+		{
+			name:        "ambiguous title prefix",
+			ref:         "First",
+			expectError: true,
+			errorMsg:    "matches 2 notes",
+		},
+		{
+			name:        "no match",
+			ref:         "missing",
+			expectError: true,
+			errorMsg:    "no note matches 'missing'",
+		},
+		{
+			name:        "short hex is not read as a hash",
+			ref:         "abc",
+			expectError: true,
+			errorMsg:    "no note matches",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, noteRepo, _ := createTestHandler()
+			noteRepo.notesWithTags = append(createTestNotes(), &note.NoteWithTags{
+				ID:      4,
+				Title:   "First steps",
+				Slug:    "first-steps",
+				Content: "Getting started",
+				Tags:    []string{},
+			})
+
+			err := h.GetNote(tt.ref, false, false)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				} else if !contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error message to contain '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+
+	t.Run("ambiguous error lists the candidates", func(t *testing.T) {
+		h, noteRepo, _ := createTestHandler()
+		noteRepo.notesWithTags = append(createTestNotes(), &note.NoteWithTags{ID: 4, Title: "First steps", Slug: "first-steps"})
+
+		err := h.DeleteNote("fir")
+
+		var ambiguous *handler.AmbiguousNoteError
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("Expected an ambiguous note error, got %v", err)
+		}
+		if len(ambiguous.Candidates) != 2 {
+			t.Errorf("Expected 2 candidates, got %d", len(ambiguous.Candidates))
+		}
+		if !contains(err.Error(), "#4  first-steps  First steps") {
+			t.Errorf("Expected candidates in the message, got '%s'", err.Error())
+		}
+	})
+}
+
+func TestNoteSlugs(t *testing.T) {
+	noteRepo, _ := newTestRepositories(t)
+
+	first := note.NewNote("Standup notes", "monday")
+	second := note.NewNote("Standup Notes", "tuesday")
+	for _, n := range []*note.Note{first, second} {
+		if err := noteRepo.Create(n); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+	}
+
+	if first.Slug != "standup-notes" {
+		t.Errorf("Expected slug 'standup-notes', got '%s'", first.Slug)
+	}
+	if second.Slug != "standup-notes-2" {
+		t.Errorf("Expected slug 'standup-notes-2', got '%s'", second.Slug)
+	}
+
+	stored, err := noteRepo.GetByID(second.ID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if stored.Slug != second.Slug {
+		t.Errorf("Expected stored slug '%s', got '%s'", second.Slug, stored.Slug)
+	}
+}
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/search"
+)
+
+func mustParse(t testing.TB, input string) *search.Query {
+	t.Helper()
+
+	query, err := search.Parse(input)
+	if err != nil {
+		t.Fatalf("failed to parse query %q: %v", input, err)
+	}
+	return query
+}
+
+func TestSearch(t *testing.T) {
+	noteRepo, _ := newTestRepositories(t)
+
+	seed := []*note.Note{
+		note.NewNote("Grocery list", "milk, eggs and a note about kubernetes"),
+		note.NewNote("Kubernetes rollout", "restart the kubernetes deployment after the kubernetes upgrade"),
+		note.NewNote("Unrelated", "nothing to see here"),
+	}
+	for _, n := range seed {
+		if err := noteRepo.Create(n); err != nil {
+			t.Fatalf("failed to seed note: %v", err)
+		}
+	}
+
+	t.Run("ranks title matches first", func(t *testing.T) {
+		results, err := noteRepo.Search(mustParse(t, "kubernetes"))
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("Expected 2 results, got %d", len(results))
+		}
+		if results[0].ID != seed[1].ID {
+			t.Errorf("Expected note #%d to rank first, got #%d", seed[1].ID, results[0].ID)
+		}
+	})
+
+	t.Run("marks matched terms", func(t *testing.T) {
+		results, err := noteRepo.Search(mustParse(t, "eggs"))
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result, got %d", len(results))
+		}
+		if !strings.Contains(results[0].Snippet, note.MatchStart+"eggs"+note.MatchEnd) {
+			t.Errorf("Expected snippet to highlight 'eggs', got '%s'", results[0].Snippet)
+		}
+	})
+
+	t.Run("index follows updates and deletes", func(t *testing.T) {
+		if err := noteRepo.Update(seed[2].ID, "now mentions eggs too", ""); err != nil {
+			t.Fatalf("failed to update note: %v", err)
+		}
+		if err := noteRepo.Delete(seed[0].ID); err != nil {
+			t.Fatalf("failed to delete note: %v", err)
+		}
+
+		results, err := noteRepo.Search(mustParse(t, "eggs"))
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != seed[2].ID {
+			t.Errorf("Expected only note #%d to match, got %+v", seed[2].ID, results)
+		}
+	})
+}
+
+func TestSearch_QueryLanguage(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+
+	seed := []struct {
+		title, content string
+		tags           []string
+		age            time.Duration
+	}{
+		{"Deploy api", "rollout of the api service", []string{"work"}, 0},
+		{"Deploy blog", "static site rollout", []string{"personal"}, 0},
+		{"Old runbook", "deploy with the legacy scripts", []string{"work", "old"}, 90 * 24 * time.Hour},
+	}
+	ids := make([]int, len(seed))
+	for i, s := range seed {
+		n := note.NewNote(s.title, s.content)
+		n.CreatedAt = n.CreatedAt.Add(-s.age)
+		n.UpdatedAt = n.CreatedAt
+		if err := noteRepo.Create(n); err != nil {
+			t.Fatalf("failed to seed note: %v", err)
+		}
+		for _, name := range s.tags {
+			tg, err := tagRepo.GetOrCreate(name)
+			if err != nil {
+				t.Fatalf("failed to seed tag: %v", err)
+			}
+			if err := noteRepo.AddTagToNote(n.ID, tg.ID); err != nil {
+				t.Fatalf("failed to tag note: %v", err)
+			}
+		}
+		ids[i] = n.ID
+	}
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"tag:work", []int{ids[0], ids[2]}},
+		{"tag:work -tag:old", []int{ids[0]}},
+		{"title:deploy", []int{ids[0], ids[1]}},
+		{"deploy updated:<7d", []int{ids[0], ids[1]}},
+		{"created:>30d", []int{ids[2]}},
+		{`"static site"`, []int{ids[1]}},
+		{"blog OR runbook", []int{ids[1], ids[2]}},
+		{"roll*", []int{ids[0], ids[1]}},
+		{"-deploy", nil},
+		{`'); DROP TABLE notes; --`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := noteRepo.Search(mustParse(t, tt.query))
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			got := map[int]bool{}
+			for _, r := range results {
+				got[r.ID] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d result(s), got %d", len(tt.want), len(got))
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("Expected note #%d in results", id)
+				}
+			}
+		})
+	}
+}
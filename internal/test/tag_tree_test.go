@@ -0,0 +1,153 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/repository"
+	"github.com/matheuzgomes/Snip/internal/tag"
+)
+
+func TestTagTree(t *testing.T) {
+	noteRepo, tagRepo := newTestRepositories(t)
+
+	tagNote := func(title string, tags ...string) *note.Note {
+		n := note.NewNote(title, title)
+		if err := noteRepo.Create(n); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+		for _, name := range tags {
+			tagObj, err := tagRepo.GetOrCreate(name)
+			if err != nil {
+				t.Fatalf("failed to create tag %s: %v", name, err)
+			}
+			noteRepo.AddTagToNote(n.ID, tagObj.ID)
+		}
+		return n
+	}
+
+	tagNote("cluster", "/work//infra/k8s/")
+	tagNote("runbook", "work/infra")
+	tagNote("standup", "work")
+	tagNote("groceries", "home")
+
+	byName := func() map[string]*tag.Tag {
+		tags, err := tagRepo.GetAll()
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		m := make(map[string]*tag.Tag)
+		for _, t := range tags {
+			m[t.Name] = t
+		}
+		return m
+	}
+
+	t.Run("paths create their ancestors", func(t *testing.T) {
+		tags := byName()
+		k8s, infra, work := tags["work/infra/k8s"], tags["work/infra"], tags["work"]
+		if k8s == nil || infra == nil || work == nil {
+			t.Fatalf("Expected work, work/infra and work/infra/k8s, got %v", tags)
+		}
+		if k8s.ParentID != infra.ID || infra.ParentID != work.ID || work.ParentID != 0 {
+			t.Errorf("Expected k8s -> infra -> work, got %+v %+v %+v", k8s, infra, work)
+		}
+		if work.NoteCount != 1 || work.SubtreeCount != 3 {
+			t.Errorf("Expected work to count 1 note directly and 3 with subtags, got %d/%d", work.NoteCount, work.SubtreeCount)
+		}
+	})
+
+	t.Run("list with subtags", func(t *testing.T) {
+		work, _ := tagRepo.GetByName("work")
+
+		direct, _ := noteRepo.GetAll(true, work.ID, false)
+		if len(direct) != 1 {
+			t.Errorf("Expected 1 note tagged work, got %d", len(direct))
+		}
+
+		all, _ := noteRepo.GetAll(true, work.ID, true)
+		if len(all) != 3 {
+			t.Errorf("Expected 3 notes under work, got %d", len(all))
+		}
+	})
+
+	t.Run("rename moves the subtree", func(t *testing.T) {
+		work, _ := tagRepo.GetByName("work")
+		if err := tagRepo.Patch(work.ID, "job"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		tags := byName()
+		if tags["job/infra/k8s"] == nil || tags["work/infra"] != nil {
+			t.Errorf("Expected work/* to move to job/*, got %v", tags)
+		}
+
+		infra := tags["job/infra"]
+		if err := tagRepo.Patch(infra.ID, "ops/infra"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		tags = byName()
+		if tags["ops/infra/k8s"] == nil || tags["ops"] == nil || tags["ops/infra"].ParentID != tags["ops"].ID {
+			t.Errorf("Expected job/infra to move under a new ops tag, got %v", tags)
+		}
+	})
+
+	t.Run("prune keeps ancestors of used tags", func(t *testing.T) {
+		if _, err := tagRepo.GetOrCreate("empty/leaf"); err != nil {
+			t.Fatalf("failed to create tag: %v", err)
+		}
+
+		pruned, err := tagRepo.Prune()
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if pruned != 2 {
+			t.Errorf("Expected empty and empty/leaf to be pruned, got %d", pruned)
+		}
+
+		if _, err := tagRepo.GetByName("ops"); err != nil {
+			t.Errorf("Expected ops to be kept for its subtags, got %v", err)
+		}
+	})
+
+	t.Run("delete removes the subtree", func(t *testing.T) {
+		ops, _ := tagRepo.GetByName("ops")
+		if err := tagRepo.Delete(ops.ID); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		if _, err := tagRepo.GetByName("ops/infra/k8s"); err != repository.ErrTagNotFound {
+			t.Errorf("Expected subtags to be deleted, got %v", err)
+		}
+	})
+}
+
+func TestTagMigration_BuildsTree(t *testing.T) {
+	db := openTestDB(t)
+
+	legacy := `
+		CREATE TABLE tags (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL);
+		INSERT INTO tags (name) VALUES ('work/infra/k8s'), ('work');
+	`
+	if _, err := db.Exec(legacy); err != nil {
+		t.Fatalf("failed to build legacy schema: %v", err)
+	}
+
+	migrateTestDB(t, db)
+
+	tagRepo, _ := repository.NewTagRepository(db)
+	k8s, err := tagRepo.GetByName("work/infra/k8s")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	infra, err := tagRepo.GetByName("work/infra")
+	if err != nil {
+		t.Fatalf("Expected missing parent to be created, got: %v", err)
+	}
+	work, _ := tagRepo.GetByName("work")
+
+	if k8s.ParentID != infra.ID || infra.ParentID != work.ID {
+		t.Errorf("Expected k8s -> infra -> work, got %+v %+v %+v", k8s, infra, work)
+	}
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/render"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	opts := render.TemplateOptions{DateFormat: "2006-01-02", MarkdownWidth: 40}
+	created := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		text     string
+		data     any
+		expected string
+	}{
+		{name: "escapes", text: `{{.}}\t{{.}}`, data: "a", expected: "a\ta"},
+		{name: "escapes in actions", text: `{{.}}{{"\n"}}{{printf "%s\t" .}}`, data: "a", expected: "a\na\t"},
+		{name: "join", text: `{{join . ","}}`, data: []string{"go", "work"}, expected: "go,work"},
+		{name: "date with config format", text: `{{date .}}`, data: created, expected: "2025-03-04"},
+		{name: "date with layout", text: `{{date . "Jan 02"}}`, data: created, expected: "Mar 04"},
+		{name: "truncate", text: `{{truncate 8 .}}`, data: "Hello, World", expected: "Hello..."},
+		{name: "truncate short text", text: `{{. | truncate 20}}`, data: "Hello", expected: "Hello"},
+		{name: "wrap", text: `{{wrap 5 .}}`, data: "one two three", expected: "one\ntwo\nthree"},
+		{name: "oneline", text: `{{oneline .}}`, data: "one\n  two\tthree", expected: "one two three"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := render.ParseTemplate(tt.text, opts)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, tt.data); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "compact.tmpl"), []byte("#{{.ID}} {{.Title}}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		value       string
+		expectError bool
+		errorMsg    string
+	}{
+		{name: "inline template", value: "{{.Title}}"},
+		{name: "named template", value: "compact"},
+		{name: "missing template", value: "missing", expectError: true, errorMsg: "template 'missing' not found"},
+		{name: "invalid template", value: "{{.Title", expectError: true, errorMsg: "invalid template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := render.LoadTemplate(dir, tt.value, render.TemplateOptions{})
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				} else if !contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error message to contain '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestTemplateOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		run      func(h handler.Handler) error
+		expected string
+	}{
+		{
+			name: "list executes the template per note",
+			text: `{{.ID}}\t{{.Title}}\t{{join .Tags ","}}`,
+			run: func(h handler.Handler) error {
+				return h.ListNotes(true, false, nil, false, nil, "")
+			},
+			expected: "1\tFirst Note\twork,important\n2\tSecond Note\tpersonal\n3\tThird Note\twork,meeting\n",
+		},
+		{
+			name: "show sees links",
+			text: "{{.Title}} links={{len .Links}}\n",
+			run: func(h handler.Handler) error {
+				return h.GetNote("2", false, false)
+			},
+			expected: "Second Note links=0\n",
+		},
+		{
+			name: "find sees the highlight",
+			text: "{{.ID}} {{.Highlight}}",
+			run: func(h handler.Handler) error {
+				return h.FindNotes("Third")
+			},
+			expected: "3 Third Note\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := render.ParseTemplate(tt.text, render.TemplateOptions{})
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			var buf bytes.Buffer
+			mockNoteRepo := &mockNoteRepository{notesWithTags: createTestNotes()}
+			h := handler.NewHandler(mockNoteRepo, &mockTagRepository{}, handler.WithOutput(render.NewTemplate(tmpl, &buf)))
+
+			if err := tt.run(h); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+// TestTemplateShowExample runs the example given in the show help.
+func TestTemplateShowExample(t *testing.T) {
+	tmpl, err := render.ParseTemplate(`{{.Title}}{{"\n"}}{{markdown .Content}}`, render.TemplateOptions{MarkdownWidth: 80})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var buf bytes.Buffer
+	mockNoteRepo := &mockNoteRepository{notesWithTags: createTestNotes()}
+	h := handler.NewHandler(mockNoteRepo, &mockTagRepository{}, handler.WithOutput(render.NewTemplate(tmpl, &buf)))
+	if err := h.GetNote("1", false, false); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "First Note\n") || !strings.Contains(buf.String(), "first note content") {
+		t.Errorf("Expected the title then the rendered content, got %q", buf.String())
+	}
+}
@@ -1,19 +1,32 @@
 package test
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
+	"testing"
 	"time"
 
+	"github.com/matheuzgomes/Snip/internal/database"
 	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/link"
 	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/property"
+	"github.com/matheuzgomes/Snip/internal/repository"
+	"github.com/matheuzgomes/Snip/internal/revision"
+	"github.com/matheuzgomes/Snip/internal/search"
 	"github.com/matheuzgomes/Snip/internal/tag"
 )
 
 type mockNoteRepository struct {
 	notes         []*note.Note
 	notesWithTags []*note.NoteWithTags
+	revisions     []*revision.Revision
+	trash         []*note.NoteWithTags
+	links         []*link.Link
 	err           error
 }
 
@@ -39,13 +52,58 @@ func (m *mockNoteRepository) GetByID(id int) (*note.NoteWithTags, error) {
 	return nil, ErrNoteNotFound
 }
 
-func (m *mockNoteRepository) GetAll(isAsc bool, tagID int) ([]*note.NoteWithTags, error) {
+func (m *mockNoteRepository) GetAll(isAsc bool, tagID int, withSubtags bool) ([]*note.NoteWithTags, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.notesWithTags, nil
 }
 
+func (m *mockNoteRepository) Append(id int, text string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, note := range m.notesWithTags {
+		if note.ID == id {
+			if note.Content != "" && !strings.HasSuffix(note.Content, "\n") {
+				note.Content += "\n"
+			}
+			note.Content += text
+			note.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrNoteNotFound
+}
+
+func (m *mockNoteRepository) Prepend(id int, text string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, note := range m.notesWithTags {
+		if note.ID == id {
+			if !strings.HasSuffix(text, "\n") {
+				text += "\n"
+			}
+			note.Content = text + note.Content
+			note.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrNoteNotFound
+}
+
+// Journal notes are only covered against sqlite, see journal_test.go.
+func (m *mockNoteRepository) GetJournal(date string) (*note.NoteWithTags, error) {
+	return nil, m.err
+}
+
+func (m *mockNoteRepository) GetJournals(limit int) ([]*note.NoteWithTags, error) {
+	return nil, m.err
+}
+
 func (m *mockNoteRepository) Update(id int, content string, title string) error {
 	if m.err != nil {
 		return m.err
@@ -53,7 +111,9 @@ func (m *mockNoteRepository) Update(id int, content string, title string) error
 
 	for _, note := range m.notesWithTags {
 		if note.ID == id {
-			note.Title = title
+			if title != "" {
+				note.Title = title
+			}
 			note.Content = content
 			note.UpdatedAt = time.Now()
 			return nil
@@ -69,6 +129,9 @@ func (m *mockNoteRepository) Delete(id int) error {
 
 	for i, note := range m.notesWithTags {
 		if note.ID == id {
+			now := time.Now()
+			note.DeletedAt = &now
+			m.trash = append(m.trash, note)
 			m.notesWithTags = append(m.notesWithTags[:i], m.notesWithTags[i+1:]...)
 			return nil
 		}
@@ -76,21 +139,73 @@ func (m *mockNoteRepository) Delete(id int) error {
 	return ErrNoteNotFound
 }
 
-func (m *mockNoteRepository) Search(term string) ([]*note.Note, error) {
+func (m *mockNoteRepository) GetDeleted() ([]*note.NoteWithTags, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
+	return m.trash, nil
+}
+
+func (m *mockNoteRepository) Restore(id int) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for i, note := range m.trash {
+		if note.ID == id {
+			note.DeletedAt = nil
+			m.notesWithTags = append(m.notesWithTags, note)
+			m.trash = append(m.trash[:i], m.trash[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotInTrash
+}
+
+func (m *mockNoteRepository) Purge(before *time.Time) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	var kept []*note.NoteWithTags
+	for _, note := range m.trash {
+		if before != nil && !note.DeletedAt.Before(*before) {
+			kept = append(kept, note)
+		}
+	}
 
-	var results []*note.Note
+	purged := len(m.trash) - len(kept)
+	m.trash = kept
+	return purged, nil
+}
+
+func (m *mockNoteRepository) Search(query *search.Query) ([]*note.SearchResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var words []string
+	for _, term := range query.Terms() {
+		if term.IsText() {
+			words = append(words, term.Value)
+		}
+	}
+	term := strings.Join(words, " ")
+
+	var results []*note.SearchResult
 	for _, noteWithTags := range m.notesWithTags {
 		if strings.Contains(strings.ToLower(noteWithTags.Title), strings.ToLower(term)) ||
 			strings.Contains(strings.ToLower(noteWithTags.Content), strings.ToLower(term)) {
-			results = append(results, &note.Note{
-				ID:        noteWithTags.ID,
-				Title:     noteWithTags.Title,
-				Content:   noteWithTags.Content,
-				CreatedAt: noteWithTags.CreatedAt,
-				UpdatedAt: noteWithTags.UpdatedAt,
+			results = append(results, &note.SearchResult{
+				Note: note.Note{
+					ID:        noteWithTags.ID,
+					Title:     noteWithTags.Title,
+					Content:   noteWithTags.Content,
+					CreatedAt: noteWithTags.CreatedAt,
+					UpdatedAt: noteWithTags.UpdatedAt,
+				},
+				Highlight: noteWithTags.Title,
+				Snippet:   noteWithTags.Content,
 			})
 		}
 	}
@@ -125,6 +240,73 @@ func (m *mockNoteRepository) Patch(id int, title string) error {
 	return ErrNoteNotFound
 }
 
+func (m *mockNoteRepository) ApplyEdit(id int, edit *note.Edit) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, n := range m.notesWithTags {
+		if n.ID == id {
+			n.Title = edit.Title
+			n.Slug = edit.Slug
+			n.Content = edit.Content
+			n.Language = edit.Language
+			n.Tags = edit.Tags
+			n.Properties = edit.Properties
+			n.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrNoteNotFound
+}
+
+func (m *mockNoteRepository) SetLanguage(id int, language string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, n := range m.notesWithTags {
+		if n.ID == id {
+			n.Language = language
+			return nil
+		}
+	}
+	return ErrNoteNotFound
+}
+
+func (m *mockNoteRepository) SetProperties(noteID int, props property.Properties) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, n := range m.notesWithTags {
+		if n.ID == noteID {
+			for _, p := range props {
+				n.Properties = n.Properties.Set(p)
+			}
+			return nil
+		}
+	}
+	return ErrNoteNotFound
+}
+
+func (m *mockNoteRepository) UnsetProperties(noteID int, keys []string) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	for _, n := range m.notesWithTags {
+		if n.ID == noteID {
+			before := len(n.Properties)
+			n.Properties = slices.DeleteFunc(n.Properties, func(p *property.Property) bool {
+				return slices.Contains(keys, p.Key)
+			})
+			return before - len(n.Properties), nil
+		}
+	}
+	return 0, ErrNoteNotFound
+}
+
 func (m *mockNoteRepository) GetRecent(limit int) ([]*note.NoteWithTags, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -142,16 +324,16 @@ func (m *mockNoteRepository) GetRecent(limit int) ([]*note.NoteWithTags, error)
 	return m.notesWithTags[start:], nil
 }
 
-func (m *mockNoteRepository) ExportNotes(exportDir string, since *time.Time, format string) error {
+func (m *mockNoteRepository) ExportNotes(exportDir string, since *time.Time, format string) (int, error) {
 	if m.err != nil {
-		return m.err
+		return 0, m.err
 	}
 
 	if format != "json" && format != "markdown" {
-		return fmt.Errorf("invalid format: %s", format)
+		return 0, fmt.Errorf("invalid format: %s", format)
 	}
 
-	return nil
+	return len(m.notesWithTags), nil
 }
 
 func (m *mockNoteRepository) AddTagToNote(noteID, tagID int) error {
@@ -166,6 +348,151 @@ func (m *mockNoteRepository) GetTagsByNote(noteID int) ([]*tag.Tag, error) {
 	return nil, nil
 }
 
+func (m *mockNoteRepository) SaveLinks(noteID int, refs []link.Ref) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	var kept []*link.Link
+	for _, l := range m.links {
+		if l.SourceID != noteID {
+			kept = append(kept, l)
+		}
+	}
+
+	for _, ref := range refs {
+		l := &link.Link{SourceID: noteID, Ref: ref.Text}
+		for _, n := range m.notesWithTags {
+			if n.ID == noteID {
+				l.SourceTitle = n.Title
+			}
+		}
+		for _, n := range m.notesWithTags {
+			if n.ID == ref.NoteID || (ref.NoteID == 0 && strings.EqualFold(n.Title, ref.Text)) {
+				l.TargetID, l.TargetTitle = n.ID, n.Title
+				break
+			}
+		}
+		kept = append(kept, l)
+	}
+
+	m.links = kept
+	return nil
+}
+
+func (m *mockNoteRepository) GetLinks(noteID int) ([]*link.Link, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var links []*link.Link
+	for _, l := range m.links {
+		if l.SourceID == noteID {
+			links = append(links, l)
+		}
+	}
+	return links, nil
+}
+
+func (m *mockNoteRepository) GetBacklinks(noteID int) ([]*link.Link, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var links []*link.Link
+	for _, l := range m.links {
+		if l.TargetID == noteID {
+			links = append(links, l)
+		}
+	}
+	return links, nil
+}
+
+func (m *mockNoteRepository) GetAllLinks() ([]*link.Link, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.links, nil
+}
+
+func (m *mockNoteRepository) SaveRevision(noteID int) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, n := range m.notesWithTags {
+		if n.ID == noteID {
+			number := 1
+			for _, rev := range m.revisions {
+				if rev.NoteID == noteID && rev.Number >= number {
+					number = rev.Number + 1
+				}
+			}
+			m.revisions = append(m.revisions, &revision.Revision{
+				ID:        len(m.revisions) + 1,
+				NoteID:    noteID,
+				Number:    number,
+				Title:     n.Title,
+				Content:   n.Content,
+				Tags:      n.Tags,
+				CreatedAt: time.Now(),
+			})
+		}
+	}
+	return nil
+}
+
+func (m *mockNoteRepository) GetRevisions(noteID int) ([]*revision.Revision, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var revisions []*revision.Revision
+	for i := len(m.revisions) - 1; i >= 0; i-- {
+		if m.revisions[i].NoteID == noteID {
+			revisions = append(revisions, m.revisions[i])
+		}
+	}
+	return revisions, nil
+}
+
+func (m *mockNoteRepository) GetRevision(noteID, number int) (*revision.Revision, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	for _, rev := range m.revisions {
+		if rev.NoteID == noteID && rev.Number == number {
+			return rev, nil
+		}
+	}
+	return nil, ErrRevisionNotFound
+}
+
+func (m *mockNoteRepository) PruneRevisions(noteID, keep int) error {
+	return m.err
+}
+
+func (m *mockNoteRepository) RevertNote(noteID int, rev *revision.Revision, refs []link.Ref, keep int) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, n := range m.notesWithTags {
+		if n.ID == noteID {
+			n.Title = rev.Title
+			n.Content = rev.Content
+			n.Tags = rev.Tags
+			n.UpdatedAt = time.Now()
+			if err := m.SaveLinks(noteID, refs); err != nil {
+				return err
+			}
+			return m.SaveRevision(noteID)
+		}
+	}
+	return ErrNoteNotFound
+}
+
 func (m *mockNoteRepository) Close() error {
 	return nil
 }
@@ -207,13 +534,24 @@ func (m *mockTagRepository) Delete(id int) error {
 	return nil
 }
 
-func (m *mockTagRepository) Update(id int, name string) error {
+func (m *mockTagRepository) Patch(id int, name string) error {
 	if m.err != nil {
 		return m.err
 	}
 	return nil
 }
 
+func (m *mockTagRepository) Merge(sourceIDs []int, targetID int) error {
+	return m.err
+}
+
+func (m *mockTagRepository) Prune() (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return 0, nil
+}
+
 func (m *mockTagRepository) GetOrCreate(name string) (*tag.Tag, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -228,6 +566,47 @@ func (m *mockTagRepository) Close() error {
 	return nil
 }
 
+func openTestDB(t testing.TB) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// migrateTestDB migrates db, failing the test when the sqlite driver lacks
+// FTS5 rather than letting most of the suite pass without running.
+func migrateTestDB(t testing.TB, db *sql.DB) []database.Migration {
+	t.Helper()
+
+	applied, err := database.Migrate(db)
+	if errors.Is(err, database.ErrFTS5Unavailable) {
+		t.Fatalf("sqlite built without FTS5, run with -tags sqlite_fts5: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	return applied
+}
+
+// newTestRepositories returns repositories backed by a fresh, migrated sqlite
+// database for tests that need real SQL behaviour.
+func newTestRepositories(t testing.TB) (repository.NoteRepository, repository.TagRepository) {
+	t.Helper()
+
+	db := openTestDB(t)
+	migrateTestDB(t, db)
+
+	noteRepo, _ := repository.NewNoteRepository(db)
+	tagRepo, _ := repository.NewTagRepository(db)
+	return noteRepo, tagRepo
+}
+
 func createTestHandler() (handler.Handler, *mockNoteRepository, *mockTagRepository) {
 	mockNoteRepo := &mockNoteRepository{}
 	mockTagRepo := &mockTagRepository{}
@@ -263,6 +642,8 @@ var (
 	ErrValidationFailed   = errors.New("validation failed")
 	ErrNoteNotFound       = errors.New("note not found")
 	ErrTagNotFound        = errors.New("no note found for this tag")
+	ErrRevisionNotFound   = errors.New("revision not found")
+	ErrNotInTrash         = errors.New("not found in trash")
 )
 
 // Helper functions to create test data
@@ -272,6 +653,7 @@ func createTestNotes() []*note.NoteWithTags {
 		{
 			ID:        1,
 			Title:     "First Note",
+			Slug:      "first-note",
 			Content:   "This is the first note content",
 			CreatedAt: now.Add(-2 * time.Hour),
 			UpdatedAt: now.Add(-1 * time.Hour),
@@ -280,6 +662,7 @@ func createTestNotes() []*note.NoteWithTags {
 		{
 			ID:        2,
 			Title:     "Second Note",
+			Slug:      "second-note",
 			Content:   "This is the second note content",
 			CreatedAt: now.Add(-1 * time.Hour),
 			UpdatedAt: now.Add(-30 * time.Minute),
@@ -288,6 +671,7 @@ func createTestNotes() []*note.NoteWithTags {
 		{
 			ID:        3,
 			Title:     "Third Note",
+			Slug:      "third-note",
 			Content:   "This is the third note content",
 			CreatedAt: now,
 			UpdatedAt: now,
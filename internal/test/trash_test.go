@@ -0,0 +1,122 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+func TestTrash(t *testing.T) {
+	t.Run("delete then restore", func(t *testing.T) {
+		h, mockNoteRepo, _ := createTestHandler()
+		mockNoteRepo.notesWithTags = createTestNotes()
+
+		if err := h.DeleteNote("1"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(mockNoteRepo.trash) != 1 {
+			t.Fatalf("Expected note to be in the trash")
+		}
+
+		if err := h.ListTrash(); err != nil {
+			t.Errorf("Expected no error but got: %v", err)
+		}
+
+		if err := h.RestoreNote("1"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(mockNoteRepo.trash) != 0 || len(mockNoteRepo.notesWithTags) != 3 {
+			t.Errorf("Expected note to be restored")
+		}
+	})
+
+	t.Run("restore note not in trash", func(t *testing.T) {
+		h, mockNoteRepo, _ := createTestHandler()
+		mockNoteRepo.notesWithTags = createTestNotes()
+
+		err := h.RestoreNote("2")
+		if err == nil || !contains(err.Error(), "not found in trash") {
+			t.Errorf("Expected 'not found in trash' error, got %v", err)
+		}
+	})
+
+	t.Run("restore invalid id", func(t *testing.T) {
+		h, _, _ := createTestHandler()
+
+		err := h.RestoreNote("abc")
+		if err == nil || !contains(err.Error(), "invalid note ID") {
+			t.Errorf("Expected 'invalid note ID' error, got %v", err)
+		}
+	})
+
+	t.Run("empty trash older than", func(t *testing.T) {
+		h, mockNoteRepo, _ := createTestHandler()
+		mockNoteRepo.notesWithTags = createTestNotes()
+		h.DeleteNote("1")
+		h.DeleteNote("2")
+
+		old := time.Now().Add(-60 * 24 * time.Hour)
+		mockNoteRepo.trash[0].DeletedAt = &old
+
+		if err := h.EmptyTrash("30d", true); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(mockNoteRepo.trash) != 1 || mockNoteRepo.trash[0].ID != 2 {
+			t.Errorf("Expected only the recently trashed note to remain")
+		}
+	})
+
+	t.Run("empty trash invalid duration", func(t *testing.T) {
+		h, _, _ := createTestHandler()
+
+		err := h.EmptyTrash("30x", true)
+		if err == nil || !contains(err.Error(), "invalid --older-than value") {
+			t.Errorf("Expected invalid duration error, got %v", err)
+		}
+	})
+}
+
+func TestTrashRepository(t *testing.T) {
+	noteRepo, _ := newTestRepositories(t)
+
+	kept := note.NewNote("Kept note", "still searchable")
+	trashed := note.NewNote("Trashed note", "searchable until deleted")
+	for _, n := range []*note.Note{kept, trashed} {
+		if err := noteRepo.Create(n); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+	}
+
+	if err := noteRepo.Delete(trashed.ID); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	all, _ := noteRepo.GetAll(false, 0, false)
+	recent, _ := noteRepo.GetRecent(10)
+	found, _ := noteRepo.Search(mustParse(t, "searchable"))
+	if len(all) != 1 || len(recent) != 1 || len(found) != 1 {
+		t.Errorf("Expected trashed note to be hidden, got list=%d recent=%d find=%d", len(all), len(recent), len(found))
+	}
+	if err := noteRepo.CheckByID(trashed.ID); err == nil {
+		t.Errorf("Expected trashed note to be hidden from CheckByID")
+	}
+
+	deleted, _ := noteRepo.GetDeleted()
+	if len(deleted) != 1 || deleted[0].DeletedAt == nil {
+		t.Fatalf("Expected 1 note in the trash, got %d", len(deleted))
+	}
+
+	past := time.Now().Add(-time.Hour)
+	if purged, _ := noteRepo.Purge(&past); purged != 0 {
+		t.Errorf("Expected nothing trashed before an hour ago, purged %d", purged)
+	}
+
+	if purged, err := noteRepo.Purge(nil); err != nil || purged != 1 {
+		t.Errorf("Expected 1 note purged, got %d (%v)", purged, err)
+	}
+
+	if err := noteRepo.Restore(trashed.ID); err == nil {
+		t.Errorf("Expected purged note to be impossible to restore")
+	}
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/tui"
+)
+
+func createTestTUI() (*tui.Model, *mockNoteRepository) {
+	mockNoteRepo := &mockNoteRepository{notesWithTags: createTestNotes()}
+	mockTagRepo := &mockTagRepository{}
+	h := handler.NewHandler(mockNoteRepo, mockTagRepo, handler.Quiet())
+
+	m := tui.New(mockNoteRepo, mockTagRepo, h, tui.Options{Notebook: "default", DateFormat: "2006-01-02"})
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	return m, mockNoteRepo
+}
+
+func pressKeys(m *tui.Model, keys ...string) {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m.Update(msg)
+	}
+}
+
+func TestTUIBrowse(t *testing.T) {
+	m, _ := createTestTUI()
+
+	view := m.View()
+	for _, expected := range []string{"Tags", "All notes", "Notes (3)", "#1 First Note", "#3 Third Note", "Preview"} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("Expected view to contain '%s':\n%s", expected, view)
+		}
+	}
+
+	pressKeys(m, "j")
+	if !strings.Contains(m.View(), "▸ #2 Second Note") || !strings.Contains(m.View(), "Tags: personal") {
+		t.Errorf("Expected the preview to follow the cursor:\n%s", m.View())
+	}
+
+	pressKeys(m, "?")
+	if !strings.Contains(m.View(), "Search as you type") {
+		t.Errorf("Expected the help screen:\n%s", m.View())
+	}
+}
+
+func TestTUISearch(t *testing.T) {
+	m, _ := createTestTUI()
+
+	pressKeys(m, "/", "T", "h", "i", "r", "d")
+	view := m.View()
+	if !strings.Contains(view, "Notes (1)") || !strings.Contains(view, "#3 Third Note") {
+		t.Errorf("Expected the list to narrow while typing:\n%s", view)
+	}
+
+	pressKeys(m, "enter")
+	if !strings.Contains(m.View(), "search: Third") {
+		t.Errorf("Expected the search to stay active:\n%s", m.View())
+	}
+
+	pressKeys(m, "esc")
+	if !strings.Contains(m.View(), "Notes (3)") {
+		t.Errorf("Expected esc to clear the search:\n%s", m.View())
+	}
+}
+
+func TestTUIChanges(t *testing.T) {
+	t.Run("delete asks before moving to the trash", func(t *testing.T) {
+		m, mockNoteRepo := createTestTUI()
+
+		pressKeys(m, "d", "n")
+		if len(mockNoteRepo.trash) != 0 {
+			t.Fatalf("Expected no note to be trashed")
+		}
+
+		pressKeys(m, "d")
+		if !strings.Contains(m.View(), "Move #1 First Note to the trash? [y/N]") {
+			t.Errorf("Expected a confirmation prompt:\n%s", m.View())
+		}
+
+		pressKeys(m, "y")
+		if len(mockNoteRepo.trash) != 1 || mockNoteRepo.trash[0].ID != 1 {
+			t.Fatalf("Expected note 1 in the trash, got %v", mockNoteRepo.trash)
+		}
+		if !strings.Contains(m.View(), "Notes (2)") || !strings.Contains(m.View(), "moved to the trash") {
+			t.Errorf("Expected the list to reload:\n%s", m.View())
+		}
+	})
+
+	t.Run("retag replaces the tags", func(t *testing.T) {
+		m, _ := createTestTUI()
+
+		pressKeys(m, "t")
+		for range "work important" {
+			pressKeys(m, "backspace")
+		}
+		pressKeys(m, "a", "r", "c", "h", "i", "v", "e", "enter")
+
+		if !strings.Contains(m.View(), "Note #1 tagged archive.") {
+			t.Errorf("Expected the note to be retagged:\n%s", m.View())
+		}
+	})
+
+	t.Run("create requires a title", func(t *testing.T) {
+		m, _ := createTestTUI()
+
+		pressKeys(m, "n", "enter")
+		if !strings.Contains(m.View(), "A title is required.") {
+			t.Errorf("Expected a missing title message:\n%s", m.View())
+		}
+	})
+}
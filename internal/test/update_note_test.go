@@ -30,7 +30,7 @@ func TestUpdateNote(t *testing.T) {
 			title:       "Updated Title",
 			setupMocks:  func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {},
 			expectError: true,
-			errorMsg:    "invalid note ID",
+			errorMsg:    "no note matches",
 		},
 		{
 			name:  "empty title",
@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/matheuzgomes/Snip/internal/fuzzy"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+)
+
+// ErrNoSelection is returned when the picker is closed without choosing.
+var ErrNoSelection = errors.New("no note selected")
+
+// Picker is a fuzzy finder over notes with a preview of the highlighted one.
+type Picker struct {
+	notes   []*note.NoteWithTags
+	matches []fuzzy.NoteMatch
+	styles  styles
+
+	width  int
+	height int
+	list   int
+
+	cursor    int
+	offset    int
+	input     textinput.Model
+	preview   viewport.Model
+	previewID int
+
+	chosen *note.NoteWithTags
+}
+
+// NewPicker returns a picker over notes starting with query typed in.
+func NewPicker(notes []*note.NoteWithTags, query string) *Picker {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.SetValue(query)
+	input.Focus()
+
+	p := &Picker{
+		notes:   notes,
+		styles:  newStyles(lipgloss.DefaultRenderer()),
+		input:   input,
+		preview: viewport.New(0, 0),
+	}
+
+	p.filter()
+	return p
+}
+
+// Pick lets the user choose one of notes. The picker draws on the terminal
+// itself rather than stdout, so the output of the command stays clean when it
+// is piped.
+func Pick(notes []*note.NoteWithTags, query string) (*note.NoteWithTags, error) {
+	if len(notes) == 0 {
+		return nil, errors.New("no notes to pick from")
+	}
+
+	p := NewPicker(notes, query)
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		defer tty.Close()
+		p.styles = newStyles(lipgloss.NewRenderer(tty))
+		programOpts = append(programOpts, tea.WithInput(tty), tea.WithOutput(tty))
+	} else if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		// systems without /dev/tty, such as Windows, can only pick on stdout
+		return nil, errors.New("no note ID given and no terminal to pick one, pass the ID")
+	}
+
+	if _, err := tea.NewProgram(p, programOpts...).Run(); err != nil {
+		return nil, fmt.Errorf("failed to run the picker: %w", err)
+	}
+
+	return p.Chosen()
+}
+
+// Chosen returns the note picked with enter.
+func (p *Picker) Chosen() (*note.NoteWithTags, error) {
+	if p.chosen == nil {
+		return nil, ErrNoSelection
+	}
+	return p.chosen, nil
+}
+
+func (p *Picker) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (p *Picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+		p.list = clamp(p.width*2/5, minListWidth, p.width)
+		p.preview.Width = max(p.width-p.list-1, 1)
+		p.preview.Height = max(p.height-1, 1)
+		p.input.Width = max(p.width-len(p.input.Prompt)-1, 1)
+		p.previewID = 0
+		p.updatePreview()
+		return p, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return p, tea.Quit
+		case "enter":
+			if len(p.matches) > 0 {
+				p.chosen = p.matches[p.cursor].Note
+			}
+			return p, tea.Quit
+		case "up", "ctrl+p", "ctrl+k":
+			p.move(-1)
+			return p, nil
+		case "down", "ctrl+n", "ctrl+j":
+			p.move(1)
+			return p, nil
+		case "pgup":
+			p.move(-p.rows())
+			return p, nil
+		case "pgdown":
+			p.move(p.rows())
+			return p, nil
+		case "shift+up":
+			p.preview.LineUp(1)
+			return p, nil
+		case "shift+down":
+			p.preview.LineDown(1)
+			return p, nil
+		}
+
+		var cmd tea.Cmd
+		query := p.input.Value()
+		p.input, cmd = p.input.Update(msg)
+		if p.input.Value() != query {
+			p.filter()
+		}
+		return p, cmd
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p *Picker) filter() {
+	p.matches = fuzzy.RankNotes(p.notes, p.input.Value())
+	p.cursor, p.offset = 0, 0
+	p.updatePreview()
+}
+
+func (p *Picker) move(delta int) {
+	p.cursor = clamp(p.cursor+delta, 0, len(p.matches)-1)
+	p.updatePreview()
+}
+
+// rows is how many matches fit below the prompt.
+func (p *Picker) rows() int {
+	return max(p.height-2, 1)
+}
+
+func (p *Picker) updatePreview() {
+	if len(p.matches) == 0 {
+		p.previewID = 0
+		p.preview.SetContent("")
+		return
+	}
+
+	n := p.matches[p.cursor].Note
+	if n.ID == p.previewID || p.preview.Width == 0 {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#%d %s\n", n.ID, n.Title)
+	if len(n.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n", strings.Join(n.Tags, ", "))
+	}
+	b.WriteString(handler.RenderMarkdown(n.Content, p.preview.Width))
+
+	p.previewID = n.ID
+	p.preview.SetContent(b.String())
+	p.preview.GotoTop()
+}
+
+func (p *Picker) View() string {
+	if p.width == 0 {
+		return ""
+	}
+
+	rows := p.rows()
+	p.offset = scrollOffset(p.offset, p.cursor, rows)
+
+	lines := []string{p.styles.dim.Render(fmt.Sprintf("  %d/%d", len(p.matches), len(p.notes)))}
+	for i := p.offset; i < len(p.matches) && i < p.offset+rows; i++ {
+		lines = append(lines, p.row(p.matches[i], i == p.cursor))
+	}
+
+	list := lipgloss.NewStyle().Width(p.list).Height(rows + 1).Render(strings.Join(lines, "\n"))
+	divider := p.styles.dim.Render(strings.TrimSuffix(strings.Repeat("│\n", rows+1), "\n"))
+
+	var preview []string
+	for line := range strings.SplitSeq(p.preview.View(), "\n") {
+		preview = append(preview, truncate(line, p.preview.Width))
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top, list, divider, strings.Join(preview, "\n"))
+	return lipgloss.JoinVertical(lipgloss.Left, p.input.View(), body)
+}
+
+// row renders a match with the matched title characters highlighted.
+func (p *Picker) row(match fuzzy.NoteMatch, selected bool) string {
+	prefix := fmt.Sprintf("#%d ", match.Note.ID)
+	title := []rune(match.Note.Title)
+
+	// keep room for the cursor marker and the id
+	if width := p.list - 2 - len(prefix); len(title) > width && width > 1 {
+		title = append(title[:width-1], '…')
+	}
+
+	var b strings.Builder
+	for i, r := range title {
+		if slices.Contains(match.TitlePositions, i) {
+			b.WriteString(p.styles.match.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	if selected {
+		return p.styles.selected.Render("▸ "+prefix) + b.String()
+	}
+	return "  " + prefix + b.String()
+}
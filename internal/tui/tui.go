@@ -0,0 +1,519 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/matheuzgomes/Snip/internal/handler"
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/repository"
+	"github.com/matheuzgomes/Snip/internal/search"
+	"github.com/matheuzgomes/Snip/internal/tag"
+)
+
+type pane int
+
+const (
+	paneTags pane = iota
+	paneNotes
+	panePreview
+)
+
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeSearch
+	modeCreate
+	modeRetag
+	modeConfirmDelete
+)
+
+// Options holds what the browser shows besides the notes themselves.
+type Options struct {
+	Notebook   string
+	DateFormat string
+}
+
+// Model is the state of the browser. Reads go straight to the repositories
+// while changes go through the handler, so links and revisions stay in sync.
+type Model struct {
+	noteRepo repository.NoteRepository
+	tagRepo  repository.TagRepository
+	handler  handler.Handler
+	opts     Options
+	styles   styles
+
+	width  int
+	height int
+	layout layout
+	focus  pane
+	mode   mode
+
+	tags      []*tag.Tag
+	tagCursor int // 0 is "All notes", i selects tags[i-1]
+	tagOffset int
+
+	notes      []*note.NoteWithTags
+	noteCursor int
+	noteOffset int
+
+	query     string
+	input     textinput.Model
+	preview   viewport.Model
+	previewID int
+
+	status   string
+	showHelp bool
+}
+
+// editorDoneMsg is sent once a handler call that took over the terminal
+// returns.
+type editorDoneMsg struct {
+	status string
+	err    error
+}
+
+// New returns a browser over the notes of the repositories. The handler should
+// be quiet (see handler.Quiet) since the browser reports outcomes itself.
+func New(noteRepo repository.NoteRepository, tagRepo repository.TagRepository, h handler.Handler, opts Options) *Model {
+	m := &Model{
+		noteRepo: noteRepo,
+		tagRepo:  tagRepo,
+		handler:  h,
+		opts:     opts,
+		styles:   newStyles(lipgloss.DefaultRenderer()),
+		focus:    paneNotes,
+		input:    textinput.New(),
+		preview:  viewport.New(0, 0),
+	}
+
+	m.refresh()
+	return m
+}
+
+// Run starts the browser on the alternate screen and blocks until it quits.
+func Run(m *Model) error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return errors.New("snip tui needs an interactive terminal")
+	}
+
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resize()
+		return m, nil
+
+	case editorDoneMsg:
+		m.status = msg.status
+		if msg.err != nil {
+			m.status = "Error: " + msg.err.Error()
+		}
+		m.refresh()
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		switch m.mode {
+		case modeSearch, modeCreate, modeRetag:
+			return m.updateInput(msg)
+		case modeConfirmDelete:
+			return m.updateConfirm(msg)
+		}
+		return m.updateBrowse(msg)
+	}
+
+	return m, nil
+}
+
+func (m *Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+
+	if m.showHelp {
+		m.showHelp = false
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "?":
+		m.showHelp = true
+	case "tab":
+		m.cycleFocus(1)
+	case "shift+tab":
+		m.cycleFocus(-1)
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup", "ctrl+u":
+		m.move(-m.layout.pageSize(m.focus))
+	case "pgdown", "ctrl+d":
+		m.move(m.layout.pageSize(m.focus))
+	case "home", "g":
+		m.move(-len(m.notes) - len(m.tags) - m.preview.TotalLineCount())
+	case "end", "G":
+		m.move(len(m.notes) + len(m.tags) + m.preview.TotalLineCount())
+	case "enter", "right", "l":
+		if m.focus != panePreview {
+			m.cycleFocus(1)
+		}
+	case "left", "h":
+		if m.focus != paneTags {
+			m.cycleFocus(-1)
+		}
+	case "esc":
+		if m.query != "" {
+			m.query = ""
+			m.loadNotes()
+		}
+	case "r":
+		m.refresh()
+	case "/":
+		m.startInput(modeSearch, "/ ", m.query)
+	case "n":
+		m.startInput(modeCreate, "Title: ", "")
+	case "e":
+		if n := m.selectedNote(); n != nil {
+			id := strconv.Itoa(n.ID)
+			return m, m.withTerminal(fmt.Sprintf("Note #%d updated.", n.ID), func() error {
+				return m.handler.UpdateNote(id, "")
+			})
+		}
+	case "t":
+		if n := m.selectedNote(); n != nil {
+			m.startInput(modeRetag, "Tags: ", strings.Join(n.Tags, " "))
+		}
+	case "d":
+		if m.selectedNote() != nil {
+			m.mode = modeConfirmDelete
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if m.mode == modeSearch {
+			m.query = ""
+			m.loadNotes()
+		}
+		m.stopInput()
+		return m, nil
+
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		current := m.mode
+		m.stopInput()
+
+		switch current {
+		case modeSearch:
+			m.focus = paneNotes
+		case modeCreate:
+			return m, m.create(value)
+		case modeRetag:
+			m.retag(value)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+
+	if m.mode == modeSearch && m.input.Value() != m.query {
+		m.query = m.input.Value()
+		m.noteCursor = 0
+		m.loadNotes()
+	}
+
+	return m, cmd
+}
+
+func (m *Model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = modeBrowse
+
+	n := m.selectedNote()
+	if n == nil || (msg.String() != "y" && msg.String() != "Y") {
+		m.status = "Cancelled."
+		return m, nil
+	}
+
+	if err := m.handler.DeleteNote(strconv.Itoa(n.ID)); err != nil {
+		m.status = "Error: " + err.Error()
+		return m, nil
+	}
+
+	m.status = fmt.Sprintf("Note #%d moved to the trash, restore it with: snip trash restore %d", n.ID, n.ID)
+	m.refresh()
+	return m, nil
+}
+
+func (m *Model) startInput(mode mode, prompt, value string) {
+	m.mode = mode
+	m.input.Prompt = prompt
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+}
+
+func (m *Model) stopInput() {
+	m.mode = modeBrowse
+	m.input.Blur()
+}
+
+// create opens the editor for a new note, tagged with the tag selected in the
+// sidebar.
+func (m *Model) create(title string) tea.Cmd {
+	if title == "" {
+		m.status = "A title is required."
+		return nil
+	}
+
+	var tags *string
+	if t := m.selectedTag(); t != nil {
+		tags = &t.Name
+	}
+
+	// new notes come first once the search is cleared
+	m.query = ""
+	m.noteCursor = 0
+
+	return m.withTerminal(fmt.Sprintf("Note '%s' created.", title), func() error {
+		return m.handler.CreateNote(title, nil, tags, "")
+	})
+}
+
+func (m *Model) retag(value string) {
+	n := m.selectedNote()
+	if n == nil {
+		return
+	}
+	if value == "" || value == strings.Join(n.Tags, " ") {
+		m.status = "Tags unchanged."
+		return
+	}
+
+	if err := m.handler.PatchNote(strconv.Itoa(n.ID), nil, &value, nil, false); err != nil {
+		m.status = "Error: " + err.Error()
+		return
+	}
+
+	m.status = fmt.Sprintf("Note #%d tagged %s.", n.ID, value)
+	m.refresh()
+}
+
+// terminalCommand runs a handler call that needs the terminal, such as one
+// opening the editor, while the browser has released it.
+type terminalCommand struct {
+	run func() error
+}
+
+func (c terminalCommand) Run() error          { return c.run() }
+func (c terminalCommand) SetStdin(io.Reader)  {}
+func (c terminalCommand) SetStdout(io.Writer) {}
+func (c terminalCommand) SetStderr(io.Writer) {}
+
+func (m *Model) withTerminal(status string, run func() error) tea.Cmd {
+	return tea.Exec(terminalCommand{run: run}, func(err error) tea.Msg {
+		return editorDoneMsg{status: status, err: err}
+	})
+}
+
+func (m *Model) cycleFocus(step int) {
+	panes := []pane{paneTags, paneNotes, panePreview}
+	if !m.layout.showTags() {
+		panes = panes[1:]
+	}
+
+	current := 0
+	for i, p := range panes {
+		if p == m.focus {
+			current = i
+		}
+	}
+
+	m.focus = panes[(current+step+len(panes))%len(panes)]
+}
+
+func (m *Model) move(delta int) {
+	switch m.focus {
+	case paneTags:
+		cursor := clamp(m.tagCursor+delta, 0, len(m.tags))
+		if cursor != m.tagCursor {
+			m.tagCursor = cursor
+			m.noteCursor = 0
+			m.loadNotes()
+		}
+	case paneNotes:
+		m.noteCursor = clamp(m.noteCursor+delta, 0, len(m.notes)-1)
+		m.updatePreview()
+	case panePreview:
+		if delta > 0 {
+			m.preview.LineDown(delta)
+		} else {
+			m.preview.LineUp(-delta)
+		}
+	}
+}
+
+func (m *Model) refresh() {
+	tags, err := m.tagRepo.GetAll()
+	if err != nil {
+		m.status = "Error: failed to fetch tags: " + err.Error()
+		return
+	}
+
+	m.tags = tags
+	m.tagCursor = clamp(m.tagCursor, 0, len(tags))
+
+	// the selected note may have been edited
+	m.previewID = 0
+	m.loadNotes()
+}
+
+func (m *Model) loadNotes() {
+	tagID := 0
+	if t := m.selectedTag(); t != nil {
+		tagID = t.ID
+	}
+
+	notes, err := m.noteRepo.GetAll(false, tagID, true)
+	if err != nil {
+		m.status = "Error: failed to fetch notes: " + err.Error()
+		return
+	}
+
+	if m.query != "" {
+		// an unfinished query such as an open quote keeps the last results
+		if notes, err = m.search(notes); err != nil {
+			m.status = err.Error()
+			return
+		}
+	}
+
+	m.notes = notes
+	m.noteCursor = clamp(m.noteCursor, 0, len(notes)-1)
+	m.updatePreview()
+}
+
+// search keeps the notes matching the query, ordered by relevance.
+func (m *Model) search(notes []*note.NoteWithTags) ([]*note.NoteWithTags, error) {
+	query, err := search.Parse(m.query)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := m.noteRepo.Search(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search notes: %w", err)
+	}
+
+	byID := make(map[int]*note.NoteWithTags, len(notes))
+	for _, n := range notes {
+		byID[n.ID] = n
+	}
+
+	var matched []*note.NoteWithTags
+	for _, r := range results {
+		if n, ok := byID[r.ID]; ok {
+			matched = append(matched, n)
+		}
+	}
+	return matched, nil
+}
+
+func (m *Model) selectedTag() *tag.Tag {
+	if m.tagCursor == 0 || m.tagCursor > len(m.tags) {
+		return nil
+	}
+	return m.tags[m.tagCursor-1]
+}
+
+func (m *Model) selectedNote() *note.NoteWithTags {
+	if m.noteCursor < 0 || m.noteCursor >= len(m.notes) {
+		return nil
+	}
+	return m.notes[m.noteCursor]
+}
+
+func (m *Model) resize() {
+	m.layout = newLayout(m.width, m.height)
+	if !m.layout.showTags() && m.focus == paneTags {
+		m.focus = paneNotes
+	}
+
+	m.preview.Width = m.layout.preview
+	m.preview.Height = m.layout.body - 1
+	m.input.Width = max(m.width-len(m.input.Prompt)-1, 1)
+
+	// markdown is rendered for the pane width
+	m.previewID = 0
+	m.updatePreview()
+}
+
+func (m *Model) updatePreview() {
+	n := m.selectedNote()
+	if n == nil {
+		m.previewID = 0
+		m.preview.SetContent("No note selected.")
+		return
+	}
+	if n.ID == m.previewID && m.preview.Width > 0 {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#%d %s\n", n.ID, n.Title)
+	if len(n.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n", strings.Join(n.Tags, ", "))
+	}
+	fmt.Fprintf(&b, "Updated: %s\n", n.UpdatedAt.Format(m.opts.DateFormat))
+	if m.preview.Width > 0 {
+		b.WriteString(handler.RenderMarkdown(n.Content, m.preview.Width))
+	}
+
+	m.previewID = n.ID
+	m.preview.SetContent(b.String())
+	m.preview.GotoTop()
+}
+
+func clamp(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
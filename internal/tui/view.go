@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	tagsWidth    = 24
+	minListWidth = 24
+	maxListWidth = 40
+	// the tag sidebar is hidden on narrower terminals
+	minTagsTerminal = 90
+)
+
+type styles struct {
+	title    lipgloss.Style
+	focus    lipgloss.Style
+	dim      lipgloss.Style
+	selected lipgloss.Style
+	error    lipgloss.Style
+	match    lipgloss.Style
+}
+
+// newStyles builds the styles for the terminal r draws on. Colors are picked
+// from the terminal's own 16 color palette, so they suit its theme without
+// querying the background, which is slow over SSH.
+func newStyles(r *lipgloss.Renderer) styles {
+	accent := lipgloss.Color("12")
+
+	return styles{
+		title:    r.NewStyle().Bold(true),
+		focus:    r.NewStyle().Bold(true).Foreground(accent),
+		dim:      r.NewStyle().Faint(true),
+		selected: r.NewStyle().Reverse(true),
+		error:    r.NewStyle().Foreground(lipgloss.Color("9")),
+		match:    r.NewStyle().Bold(true).Foreground(accent),
+	}
+}
+
+// layout holds the pane sizes for a terminal size. Every pane is separated by
+// a one column divider.
+type layout struct {
+	width   int
+	body    int
+	tags    int
+	list    int
+	preview int
+}
+
+func newLayout(width, height int) layout {
+	l := layout{width: width, body: max(height-2, 2)}
+
+	if width >= minTagsTerminal {
+		l.tags = tagsWidth
+	}
+
+	l.list = clamp(width/3, minListWidth, maxListWidth)
+	l.preview = max(width-l.list-1, 1)
+	if l.showTags() {
+		l.preview -= l.tags + 1
+	}
+
+	return l
+}
+
+func (l layout) showTags() bool {
+	return l.tags > 0
+}
+
+// pageSize is how many rows of the pane fit on screen, note rows take two
+// lines.
+func (l layout) pageSize(p pane) int {
+	rows := l.body - 1
+	if p == paneNotes {
+		rows /= 2
+	}
+	return max(rows, 1)
+}
+
+func (m *Model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	var body string
+	if m.showHelp {
+		body = lipgloss.NewStyle().Height(m.layout.body).Render(helpText)
+	} else {
+		divider := m.styles.dim.Render(strings.TrimSuffix(strings.Repeat("│\n", m.layout.body), "\n"))
+
+		var panes []string
+		if m.layout.showTags() {
+			panes = append(panes, m.viewTags(), divider)
+		}
+		panes = append(panes, m.viewNotes(), divider, m.viewPreview())
+		body = lipgloss.JoinHorizontal(lipgloss.Top, panes...)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, m.viewHeader(), body, m.viewFooter())
+}
+
+func (m *Model) viewHeader() string {
+	parts := []string{m.styles.title.Render("snip")}
+	if m.opts.Notebook != "" {
+		parts = append(parts, m.opts.Notebook)
+	}
+	parts = append(parts, fmt.Sprintf("%d note(s)", len(m.notes)))
+	if t := m.selectedTag(); t != nil {
+		parts = append(parts, "tag: "+t.Name)
+	}
+	if m.query != "" {
+		parts = append(parts, "search: "+m.query)
+	}
+
+	return truncate(strings.Join(parts, m.styles.dim.Render(" · ")), m.width)
+}
+
+func (m *Model) viewFooter() string {
+	switch m.mode {
+	case modeSearch, modeCreate, modeRetag:
+		return m.input.View()
+	case modeConfirmDelete:
+		n := m.selectedNote()
+		return truncate(fmt.Sprintf("Move #%d %s to the trash? [y/N]", n.ID, n.Title), m.width)
+	}
+
+	if strings.HasPrefix(m.status, "Error: ") {
+		return truncate(m.styles.error.Render(m.status), m.width)
+	}
+	if m.status != "" {
+		return truncate(m.status, m.width)
+	}
+	return truncate(m.styles.dim.Render("/ search  n new  e edit  t tags  d delete  tab pane  ? help  q quit"), m.width)
+}
+
+func (m *Model) viewTags() string {
+	lines := []string{m.paneTitle(paneTags, "Tags")}
+
+	rows := m.layout.pageSize(paneTags)
+	m.tagOffset = scrollOffset(m.tagOffset, m.tagCursor, rows)
+
+	for i := m.tagOffset; i <= len(m.tags) && i < m.tagOffset+rows; i++ {
+		label := "All notes"
+		if i > 0 {
+			t := m.tags[i-1]
+			depth := strings.Count(t.Name, "/")
+			label = fmt.Sprintf("%s%s (%d)", strings.Repeat("  ", depth), t.Leaf(), t.SubtreeCount)
+		}
+		lines = append(lines, m.row(paneTags, i == m.tagCursor, label, m.layout.tags))
+	}
+
+	return m.block(lines, m.layout.tags)
+}
+
+func (m *Model) viewNotes() string {
+	lines := []string{m.paneTitle(paneNotes, fmt.Sprintf("Notes (%d)", len(m.notes)))}
+
+	if len(m.notes) == 0 {
+		lines = append(lines, m.styles.dim.Render("  No notes found."))
+		return m.block(lines, m.layout.list)
+	}
+
+	rows := m.layout.pageSize(paneNotes)
+	m.noteOffset = scrollOffset(m.noteOffset, m.noteCursor, rows)
+
+	for i := m.noteOffset; i < len(m.notes) && i < m.noteOffset+rows; i++ {
+		n := m.notes[i]
+		lines = append(lines, m.row(paneNotes, i == m.noteCursor, fmt.Sprintf("#%d %s", n.ID, n.Title), m.layout.list))
+
+		detail := n.UpdatedAt.Format(m.opts.DateFormat)
+		if len(n.Tags) > 0 {
+			detail = strings.Join(n.Tags, ", ") + " · " + detail
+		}
+		lines = append(lines, m.styles.dim.Render(truncate("    "+detail, m.layout.list)))
+	}
+
+	return m.block(lines, m.layout.list)
+}
+
+func (m *Model) viewPreview() string {
+	lines := []string{m.paneTitle(panePreview, "Preview")}
+	for line := range strings.SplitSeq(m.preview.View(), "\n") {
+		lines = append(lines, truncate(line, m.layout.preview))
+	}
+
+	return m.block(lines, m.layout.preview)
+}
+
+func (m *Model) paneTitle(p pane, title string) string {
+	if m.focus == p && m.mode == modeBrowse {
+		return m.styles.focus.Render(title)
+	}
+	return m.styles.dim.Render(title)
+}
+
+// row renders a list entry, highlighted when it is under the cursor.
+func (m *Model) row(p pane, selected bool, label string, width int) string {
+	if !selected {
+		return truncate("  "+label, width)
+	}
+
+	line := truncate("▸ "+label, width)
+	if m.focus == p {
+		return m.styles.selected.Render(line)
+	}
+	return m.styles.title.Render(line)
+}
+
+// block pads lines to the pane size so panes line up when joined.
+func (m *Model) block(lines []string, width int) string {
+	if len(lines) > m.layout.body {
+		lines = lines[:m.layout.body]
+	}
+	return lipgloss.NewStyle().Width(width).Height(m.layout.body).Render(strings.Join(lines, "\n"))
+}
+
+// scrollOffset moves the first visible row just enough to keep the cursor on
+// screen.
+func scrollOffset(offset, cursor, rows int) int {
+	if cursor < offset {
+		return cursor
+	}
+	if cursor >= offset+rows {
+		return cursor - rows + 1
+	}
+	return offset
+}
+
+func truncate(s string, width int) string {
+	return ansi.Truncate(s, width, "…")
+}
+
+const helpText = `Keys
+
+  ↑/k ↓/j        Move in the focused pane
+  pgup/pgdown    Move a page (ctrl+u/ctrl+d)
+  g/G            Go to the first or last entry
+  tab/shift+tab  Switch between tags, notes and preview (also ←/→)
+  /              Search as you type, enter keeps the results, esc clears them
+  n              New note in the editor, tagged with the selected tag
+  e              Edit the selected note in the editor
+  t              Replace the tags of the selected note
+  d              Move the selected note to the trash
+  r              Reload notes and tags
+  q              Quit
+
+Press any key to go back.`
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
+
+	"github.com/matheuzgomes/Snip/internal/note"
+	"github.com/matheuzgomes/Snip/internal/tag"
 )
 
 type Validator struct {
@@ -30,6 +34,34 @@ func (v *Validator) ValidateNote(title string) error {
 	return nil
 }
 
+// ValidateSlug accepts slugs like the ones snip makes: lowercase letters and
+// digits joined by single dashes.
+func (v *Validator) ValidateSlug(slug string) error {
+	if slug == "" {
+		return &Validator{Field: "slug", Message: "is required"}
+	}
+
+	if note.Slugify(slug) != slug {
+		return &Validator{
+			Field:   "slug",
+			Message: fmt.Sprintf("'%s' is invalid, use lowercase letters and digits joined by '-'", slug),
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateTag(name string) error {
+	if tag.NormalizePath(name) == "" {
+		return &Validator{Field: "tag", Message: "cannot be empty"}
+	}
+
+	if strings.ContainsFunc(name, unicode.IsSpace) {
+		return &Validator{Field: "tag", Message: fmt.Sprintf("'%s' cannot contain spaces", name)}
+	}
+
+	return nil
+}
 
 func (v *Validator) CheckString(s string) *string {
 	if s == "" {